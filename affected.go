@@ -0,0 +1,51 @@
+package dag
+
+// AffectedOptions configures Affected.
+type AffectedOptions[T comparable] struct {
+	// Targets, if not nil, restricts the result to the affected nodes needed
+	// to rebuild these targets: the targets themselves and their ancestors.
+	Targets []T
+}
+
+// Affected returns the nodes that must be rebuilt when the nodes with data
+// changed are modified: the changed nodes and all of their descendants, in
+// topological order with ties broken by the DAG's node order. Changed or
+// target nodes that are not in the DAG are ignored.
+//
+// The whole set is found in a single traversal, so this is much faster than
+// calling Descendants for every changed node and sorting the union.
+func (d *DAG[T]) Affected(changed []T, opts AffectedOptions[T]) []*Node[T] {
+	affected := make(map[*Node[T]]struct{})
+	walkFrom(d.existingNodes(changed), true, func(node *Node[T]) bool {
+		affected[node] = struct{}{}
+		return true
+	})
+
+	if opts.Targets != nil {
+		// Every node between an affected node and a target is affected, so
+		// the search up from the targets can stop at unaffected nodes
+		needed := make(map[*Node[T]]struct{})
+		walkFrom(d.existingNodes(opts.Targets), false, func(node *Node[T]) bool {
+			if _, ok := affected[node]; !ok {
+				return false
+			}
+			needed[node] = struct{}{}
+			return true
+		})
+		affected = needed
+	}
+
+	return d.orderRange(affected, RangeOptions[T]{})
+}
+
+// existingNodes returns the nodes with the given data, skipping data that
+// is not in the DAG.
+func (d *DAG[T]) existingNodes(data []T) []*Node[T] {
+	var nodes []*Node[T]
+	for _, v := range data {
+		if node := d.nodes[v]; node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
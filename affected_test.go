@@ -0,0 +1,81 @@
+package dag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newMonorepo builds a graph with an edge from each package to the
+// packages that depend on it.
+func newMonorepo() *DAG[string] {
+	dag := NewDAG[string]()
+	dag.AddEdge("util", "core")
+	dag.AddEdge("util", "cli")
+	dag.AddEdge("core", "api")
+	dag.AddEdge("core", "worker")
+	dag.AddEdge("api", "api_test")
+	dag.AddEdge("worker", "worker_test")
+	dag.AddNode("docs")
+	return dag
+}
+
+func TestAffected(t *testing.T) {
+	dag := newMonorepo()
+
+	tests := []struct {
+		changed  []string
+		targets  []string
+		expected []string
+	}{
+		{[]string{"core"}, nil, []string{"core", "api", "api_test", "worker", "worker_test"}},
+		{[]string{"util", "missing"}, nil, []string{"util", "cli", "core", "api", "api_test", "worker", "worker_test"}},
+		{[]string{"api", "worker"}, nil, []string{"api", "api_test", "worker", "worker_test"}},
+		{[]string{"docs"}, nil, []string{"docs"}},
+		{[]string{"core"}, []string{"api_test"}, []string{"core", "api", "api_test"}},
+		{[]string{"util"}, []string{"cli", "worker"}, []string{"util", "cli", "core", "worker"}},
+		{[]string{"core"}, []string{"cli", "docs"}, nil},
+		{[]string{"core"}, []string{}, nil},
+		{nil, nil, nil},
+	}
+	for _, tt := range tests {
+		affected := dag.Affected(tt.changed, AffectedOptions[string]{Targets: tt.targets})
+		assert.Equal(t, tt.expected, nodeData(affected), "Unexpected result for %v with targets %v", tt.changed, tt.targets)
+	}
+}
+
+func TestAffectedMatchesDescendants(t *testing.T) {
+	dag := NewDAG(WithOrdered[int]())
+	for _, edge := range benchmarkEdges(200) {
+		dag.AddEdge(edge[0], edge[1])
+	}
+	changed := []int{5, 17, 120}
+
+	expected := make(map[int]struct{})
+	for _, c := range changed {
+		expected[c] = struct{}{}
+		for _, node := range dag.Descendants(c) {
+			expected[node.Data()] = struct{}{}
+		}
+	}
+
+	affected := nodeData(dag.Affected(changed, AffectedOptions[int]{}))
+	assert.Len(t, affected, len(expected))
+	for i, data := range affected {
+		assert.Contains(t, expected, data)
+		for _, before := range affected[:i] {
+			assert.False(t, dag.HasPath(data, before), "Expected %d to come after %d", before, data)
+		}
+	}
+}
+
+func BenchmarkAffected(b *testing.B) {
+	dag := NewDAG(WithOrdered[int]())
+	for _, edge := range benchmarkEdges(benchmarkNodes) {
+		dag.AddEdge(edge[0], edge[1])
+	}
+	changed := []int{1, 10, 100}
+	for b.Loop() {
+		dag.Affected(changed, AffectedOptions[int]{})
+	}
+}
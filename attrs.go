@@ -0,0 +1,106 @@
+package dag
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Attributes holds string key/value pairs attached to a node or edge, such
+// as a color, owner or edge kind. The zero value is an empty set ready to use.
+type Attributes struct {
+	values map[string]string
+}
+
+// Get returns the value stored under key, and whether it exists.
+func (a *Attributes) Get(key string) (string, bool) {
+	value, exists := a.values[key]
+	return value, exists
+}
+
+// Set stores value under key, replacing any existing value.
+func (a *Attributes) Set(key, value string) {
+	if a.values == nil {
+		a.values = make(map[string]string)
+	}
+	a.values[key] = value
+}
+
+// Delete removes the value stored under key, if any.
+func (a *Attributes) Delete(key string) {
+	delete(a.values, key)
+}
+
+// Len returns the number of attributes.
+func (a *Attributes) Len() int {
+	return len(a.values)
+}
+
+// All returns an iterator over the attributes, sorted by key.
+func (a *Attributes) All() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		for _, key := range slices.Sorted(maps.Keys(a.values)) {
+			if !yield(key, a.values[key]) {
+				return
+			}
+		}
+	}
+}
+
+// copyFrom replaces the attributes with a copy of other's.
+func (a *Attributes) copyFrom(other *Attributes) {
+	a.values = maps.Clone(other.values)
+}
+
+// Attrs returns the attributes of the node.
+func (n *Node[T]) Attrs() *Attributes {
+	return &n.attrs
+}
+
+// EdgeAttrs returns the attributes of the edge from the node with data 'from'
+// to the node with data 'to', or nil if the edge doesn't exist.
+func (d *DAG[T]) EdgeAttrs(from, to T) *Attributes {
+	fromNode := d.nodes[from]
+	toNode := d.nodes[to]
+	if fromNode == nil || toNode == nil {
+		return nil
+	}
+	if _, exists := fromNode.children[toNode]; !exists {
+		return nil
+	}
+	return fromNode.edgeAttrsTo(toNode)
+}
+
+// edgeAttrsTo returns the attributes of the edge to child, creating them
+// if needed. The edge must exist.
+func (n *Node[T]) edgeAttrsTo(child *Node[T]) *Attributes {
+	if n.edgeAttrs == nil {
+		n.edgeAttrs = make(map[*Node[T]]*Attributes)
+	}
+	attrs := n.edgeAttrs[child]
+	if attrs == nil {
+		attrs = &Attributes{}
+		n.edgeAttrs[child] = attrs
+	}
+	return attrs
+}
+
+// Clone returns a deep copy of the DAG, including node and edge attributes,
+// edge weights and the node ordering. Observers are not copied.
+func (d *DAG[T]) Clone() *DAG[T] {
+	all := make(map[*Node[T]]struct{}, len(d.nodes))
+	for _, node := range d.nodes {
+		all[node] = struct{}{}
+	}
+	return d.copySelected(all)
+}
+
+// copyEdgeAttrs copies the attributes of the edge from -> to, which belongs
+// to another DAG, onto the same edge in this DAG. The edge must exist.
+func (d *DAG[T]) copyEdgeAttrs(from, to *Node[T]) {
+	attrs := from.edgeAttrs[to]
+	if attrs == nil || attrs.Len() == 0 {
+		return
+	}
+	d.nodes[from.data].edgeAttrsTo(d.nodes[to.data]).copyFrom(attrs)
+}
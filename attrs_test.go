@@ -0,0 +1,187 @@
+package dag
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributes(t *testing.T) {
+	var attrs Attributes
+	_, exists := attrs.Get("color")
+	assert.False(t, exists, "Expected the zero value to be empty")
+
+	attrs.Set("color", "red")
+	attrs.Set("owner", "infra")
+	attrs.Set("color", "blue")
+	value, exists := attrs.Get("color")
+	assert.True(t, exists)
+	assert.Equal(t, "blue", value)
+	assert.Equal(t, 2, attrs.Len())
+
+	attrs.Set("cost", "3")
+	attrs.Delete("owner")
+	assert.Equal(t, map[string]string{"color": "blue", "cost": "3"}, maps.Collect(attrs.All()))
+
+	var keys []string
+	for key := range attrs.All() {
+		keys = append(keys, key)
+	}
+	assert.Equal(t, []string{"color", "cost"}, keys, "Expected keys in sorted order")
+}
+
+func TestNodeAndEdgeAttrs(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("A", "C")
+
+	dag.Node("B").Attrs().Set("owner", "infra")
+	dag.Node("C").Attrs().Set("owner", "web")
+	dag.EdgeAttrs("A", "B").Set("kind", "build")
+
+	kind, _ := dag.EdgeAttrs("A", "B").Get("kind")
+	assert.Equal(t, "build", kind)
+	assert.Equal(t, 0, dag.EdgeAttrs("A", "C").Len())
+	assert.Nil(t, dag.EdgeAttrs("B", "A"), "Expected nil for a missing edge")
+	assert.Nil(t, dag.EdgeAttrs("A", "X"), "Expected nil for a missing node")
+
+	// Attributes can be used in search predicates
+	found := dag.Search(func(node *Node[string]) bool {
+		owner, _ := node.Attrs().Get("owner")
+		return owner == "web"
+	})
+	assert.Equal(t, "C", found.Data())
+
+	// Removing and re-adding an edge starts with no attributes
+	dag.RemoveEdge("A", "B")
+	assert.NoError(t, dag.AddEdge("A", "B"))
+	assert.Equal(t, 0, dag.EdgeAttrs("A", "B").Len())
+
+	// Removing a node drops its incoming edge attributes
+	dag.EdgeAttrs("A", "C").Set("kind", "test")
+	dag.RemoveNode("C")
+	dag.AddEdge("A", "C")
+	assert.Equal(t, 0, dag.EdgeAttrs("A", "C").Len())
+	assert.Equal(t, 0, dag.Node("C").Attrs().Len())
+}
+
+func TestClone(t *testing.T) {
+	dag := NewDAG(WithInsertionOrder[string]())
+	dag.AddEdge("C", "B")
+	dag.AddWeightedEdge("B", "A", 2.5)
+	dag.AddNode("D")
+	dag.Node("B").Attrs().Set("color", "red")
+	dag.EdgeAttrs("C", "B").Set("kind", "build")
+
+	clone := dag.Clone()
+	assert.Equal(t, nodeData(dag.Nodes()), nodeData(clone.Nodes()), "Expected the same nodes in the same order")
+	assert.Equal(t, dag.Visualize(), clone.Visualize())
+	weight, _ := clone.EdgeWeight("B", "A")
+	assert.Equal(t, 2.5, weight)
+	assertTopologicalOrder(t, clone)
+
+	// The clone is independent of the original
+	clone.Node("B").Attrs().Set("color", "blue")
+	clone.EdgeAttrs("C", "B").Delete("kind")
+	clone.AddEdge("A", "D")
+	color, _ := dag.Node("B").Attrs().Get("color")
+	assert.Equal(t, "red", color)
+	kind, _ := dag.EdgeAttrs("C", "B").Get("kind")
+	assert.Equal(t, "build", kind)
+	assert.False(t, dag.HasEdge("A", "D"))
+}
+
+func TestAttrsTransitive(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("B", "C")
+	dag.AddEdge("A", "C")
+	dag.Node("A").Attrs().Set("color", "red")
+	dag.EdgeAttrs("A", "B").Set("kind", "build")
+	dag.EdgeAttrs("A", "C").Set("kind", "test")
+
+	reduced := dag.TransitiveReduction()
+	color, _ := reduced.Node("A").Attrs().Get("color")
+	assert.Equal(t, "red", color)
+	kind, _ := reduced.EdgeAttrs("A", "B").Get("kind")
+	assert.Equal(t, "build", kind)
+
+	closure := reduced.TransitiveClosure()
+	kind, _ = closure.EdgeAttrs("A", "B").Get("kind")
+	assert.Equal(t, "build", kind)
+	assert.Equal(t, 0, closure.EdgeAttrs("A", "C").Len(), "Expected no attributes on added edges")
+}
+
+func TestAttrsJSON(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("B", "C")
+	dag.Node("B").Attrs().Set("color", "red")
+	dag.EdgeAttrs("B", "C").Set("kind", "build")
+
+	data, err := json.Marshal(dag)
+	assert.NoError(t, err)
+	expected := `{"nodes":["A","B","C"],"edges":[{"from":0,"to":1},{"from":1,"to":2,"attrs":{"kind":"build"}}],"nodeAttrs":{"1":{"color":"red"}}}`
+	assert.JSONEq(t, expected, string(data))
+
+	var decoded DAG[string]
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, dag.Visualize(), decoded.Visualize())
+
+	err = json.Unmarshal([]byte(`{"nodes":["A"],"edges":[],"nodeAttrs":{"3":{"color":"red"}}}`), &decoded)
+	assert.ErrorContains(t, err, "node index out of range")
+}
+
+func TestAttrsDOT(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("B", "C")
+	dag.Node("B").Attrs().Set("label", `say "hi"`)
+	dag.Node("B").Attrs().Set("color", "red")
+	dag.EdgeAttrs("B", "C").Set("style", "dashed")
+
+	expected := `digraph G {
+    "B" [color="red", label="say \"hi\""];
+    "A" -> "B";
+    "B" -> "C" [style="dashed"];
+}
+`
+	assert.Equal(t, expected, dag.Visualize())
+
+	// Attributes survive a round trip through the DOT parser
+	graph, err := ParseDOT(strings.NewReader(dag.Visualize()))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, graph.DAG.Visualize())
+}
+
+func TestAttrsRollback(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("B", "C")
+	dag.EdgeAttrs("A", "B").Set("kind", "build")
+	dag.EdgeAttrs("B", "C").Set("kind", "test")
+
+	err := dag.Update(func(tx *Tx[string]) error {
+		tx.RemoveNode("B")
+		tx.RemoveEdge("A", "C")
+		return errors.New("abort")
+	})
+	assert.Error(t, err)
+
+	kind, _ := dag.EdgeAttrs("A", "B").Get("kind")
+	assert.Equal(t, "build", kind)
+	kind, _ = dag.EdgeAttrs("B", "C").Get("kind")
+	assert.Equal(t, "test", kind)
+
+	err = dag.Update(func(tx *Tx[string]) error {
+		tx.RemoveEdge("A", "B")
+		return errors.New("abort")
+	})
+	assert.Error(t, err)
+	kind, _ = dag.EdgeAttrs("A", "B").Get("kind")
+	assert.Equal(t, "build", kind)
+}
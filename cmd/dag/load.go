@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/p0pr0ck5/go-dag"
+)
+
+// Input formats accepted by -format.
+const (
+	formatAuto  = "auto"
+	formatEdges = "edges"
+	formatJSON  = "json"
+	formatDOT   = "dot"
+)
+
+// detectFormat picks an input format from the file extension,
+// falling back to an edge list.
+func detectFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return formatJSON
+	case ".dot", ".gv":
+		return formatDOT
+	default:
+		return formatEdges
+	}
+}
+
+// load reads a graph from r in the given format.
+func load(r io.Reader, format string) (*dag.DAG[string], error) {
+	switch format {
+	case formatEdges:
+		return loadEdges(r)
+	case formatJSON:
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		g := dag.NewDAG[string]()
+		if err := json.Unmarshal(bytes.TrimSpace(data), g); err != nil {
+			return nil, err
+		}
+		return g, nil
+	case formatDOT:
+		graph, err := dag.ParseDOT(r)
+		if err != nil {
+			return nil, err
+		}
+		return graph.DAG, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// loadEdges reads an edge list with one edge per line, written as either
+// "from to" or "from -> to". A line with a single ID declares an isolated
+// node. Blank lines and lines starting with '#' are ignored.
+func loadEdges(r io.Reader) (*dag.DAG[string], error) {
+	g := dag.NewDAG[string]()
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) == 3 && fields[1] == "->" {
+			fields = []string{fields[0], fields[2]}
+		}
+		switch len(fields) {
+		case 1:
+			g.AddNode(fields[0])
+		case 2:
+			if err := g.AddEdge(fields[0], fields[1]); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		default:
+			return nil, fmt.Errorf("line %d: expected \"from to\" or \"from -> to\", found %q", line, text)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
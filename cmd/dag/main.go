@@ -0,0 +1,291 @@
+// Command dag inspects and transforms DAG files using the go-dag library.
+//
+// Usage:
+//
+//	dag [-in file] [-format auto|edges|json|dot] <command> [args]
+//
+// Graphs are read from -in (standard input by default) as an edge list,
+// JSON document or Graphviz digraph. The format is detected from the file
+// extension unless -format is given.
+//
+// Commands:
+//
+//	toposort                print nodes in topological order
+//	levels                  print each level of the graph on its own line
+//	roots                   print nodes with no parents
+//	leaves                  print nodes with no children
+//	ancestors <node>        print every ancestor of node
+//	descendants <node>      print every descendant of node
+//	path <from> <to>        print the shortest path between two nodes
+//	has-path <from> <to>    print whether a path exists; exits 1 if not
+//	range <expr>            print a git-style range such as "B ^A", A..B or A...B
+//	dot                     print the graph in DOT format
+//	tree [<node>]           draw the graph, or the part below node, as a tree
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/p0pr0ck5/go-dag"
+)
+
+// errNoPath is returned by has-path when no path exists, so that main can
+// exit with a non-zero status without printing an error.
+var errNoPath = errors.New("no path")
+
+type command struct {
+	args []string
+	// optional names trailing arguments that may be omitted.
+	optional []string
+	usage    string
+	run      func(g *dag.DAG[string], args []string, w io.Writer) error
+}
+
+var commands = map[string]command{
+	"toposort": {
+		usage: "print nodes in topological order",
+		run: func(g *dag.DAG[string], args []string, w io.Writer) error {
+			sorted, err := g.Traverse()
+			if err != nil {
+				return err
+			}
+			return printNodes(w, sorted, false)
+		},
+	},
+	"levels": {
+		usage: "print each level of the graph on its own line",
+		run: func(g *dag.DAG[string], args []string, w io.Writer) error {
+			for _, level := range g.LevelOrder() {
+				names := make([]string, len(level))
+				for i, node := range level {
+					names[i] = node.Data()
+				}
+				if _, err := fmt.Fprintln(w, strings.Join(names, " ")); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	"roots": {
+		usage: "print nodes with no parents",
+		run: func(g *dag.DAG[string], args []string, w io.Writer) error {
+			return printNodes(w, g.Roots(), true)
+		},
+	},
+	"leaves": {
+		usage: "print nodes with no children",
+		run: func(g *dag.DAG[string], args []string, w io.Writer) error {
+			return printNodes(w, g.Leaves(), true)
+		},
+	},
+	"ancestors": {
+		args:  []string{"node"},
+		usage: "print every ancestor of node",
+		run: func(g *dag.DAG[string], args []string, w io.Writer) error {
+			if err := requireNodes(g, args...); err != nil {
+				return err
+			}
+			return printNodes(w, g.Ancestors(args[0]), true)
+		},
+	},
+	"descendants": {
+		args:  []string{"node"},
+		usage: "print every descendant of node",
+		run: func(g *dag.DAG[string], args []string, w io.Writer) error {
+			if err := requireNodes(g, args...); err != nil {
+				return err
+			}
+			return printNodes(w, g.Descendants(args[0]), true)
+		},
+	},
+	"path": {
+		args:  []string{"from", "to"},
+		usage: "print the shortest path between two nodes",
+		run: func(g *dag.DAG[string], args []string, w io.Writer) error {
+			if err := requireNodes(g, args...); err != nil {
+				return err
+			}
+			path := g.ShortestPath(args[0], args[1])
+			if path == nil {
+				return fmt.Errorf("no path from %q to %q", args[0], args[1])
+			}
+			return printNodes(w, path, false)
+		},
+	},
+	"has-path": {
+		args:  []string{"from", "to"},
+		usage: "print whether a path exists; exits 1 if not",
+		run: func(g *dag.DAG[string], args []string, w io.Writer) error {
+			if err := requireNodes(g, args...); err != nil {
+				return err
+			}
+			found := g.HasPath(args[0], args[1])
+			if _, err := fmt.Fprintln(w, found); err != nil {
+				return err
+			}
+			if !found {
+				return errNoPath
+			}
+			return nil
+		},
+	},
+	"range": {
+		args:  []string{"expr"},
+		usage: `print a git-style range such as "B ^A", A..B or A...B`,
+		run: func(g *dag.DAG[string], args []string, w io.Writer) error {
+			r, err := dag.ParseRevRange(args[0])
+			if err != nil {
+				return err
+			}
+			if err := requireNodes(g, append(r.Include, r.Exclude...)...); err != nil {
+				return err
+			}
+			return printNodes(w, g.Range(r, dag.RangeOptions[string]{}), false)
+		},
+	},
+	"dot": {
+		usage: "print the graph in DOT format",
+		run: func(g *dag.DAG[string], args []string, w io.Writer) error {
+			_, err := io.WriteString(w, g.Visualize())
+			return err
+		},
+	},
+	"tree": {
+		optional: []string{"node"},
+		usage:    "draw the graph, or the part below node, as a tree",
+		run: func(g *dag.DAG[string], args []string, w io.Writer) error {
+			if err := requireNodes(g, args...); err != nil {
+				return err
+			}
+			opts := dag.TreeOptions[string]{}
+			if len(args) > 0 {
+				opts.Start = args
+			}
+			_, err := io.WriteString(w, g.VisualizeTree(opts))
+			return err
+		},
+	},
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	switch {
+	case err == nil:
+	case errors.Is(err, errNoPath):
+		os.Exit(1)
+	case errors.Is(err, flag.ErrHelp):
+		os.Exit(2)
+	default:
+		fmt.Fprintln(os.Stderr, "dag:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses args, loads the graph and runs the selected command.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("dag", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	in := flags.String("in", "-", "graph file to read, or - for standard input")
+	format := flags.String("format", formatAuto, "input format: auto, edges, json or dot")
+	flags.Usage = func() { usage(flags) }
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		usage(flags)
+		return flag.ErrHelp
+	}
+	name, cmdArgs := flags.Arg(0), flags.Args()[1:]
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q", name)
+	}
+	if len(cmdArgs) < len(cmd.args) || len(cmdArgs) > len(cmd.args)+len(cmd.optional) {
+		return fmt.Errorf("usage: dag %s", synopsis(name, cmd))
+	}
+
+	r := stdin
+	if *in != "-" {
+		f, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	if *format == formatAuto {
+		*format = detectFormat(*in)
+	}
+
+	g, err := load(r, *format)
+	if err != nil {
+		return err
+	}
+	return cmd.run(g, cmdArgs, stdout)
+}
+
+func usage(flags *flag.FlagSet) {
+	w := flags.Output()
+	fmt.Fprintln(w, "usage: dag [-in file] [-format auto|edges|json|dot] <command> [args]")
+	fmt.Fprintln(w, "\nflags:")
+	flags.PrintDefaults()
+	fmt.Fprintln(w, "\ncommands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		cmd := commands[name]
+		fmt.Fprintf(w, "  %-24s%s\n", synopsis(name, cmd), cmd.usage)
+	}
+}
+
+// synopsis formats a command and its arguments for usage messages.
+func synopsis(name string, cmd command) string {
+	parts := []string{name}
+	for _, arg := range cmd.args {
+		parts = append(parts, "<"+arg+">")
+	}
+	for _, arg := range cmd.optional {
+		parts = append(parts, "[<"+arg+">]")
+	}
+	return strings.Join(parts, " ")
+}
+
+// requireNodes returns an error naming the first of names missing from g.
+func requireNodes(g *dag.DAG[string], names ...string) error {
+	for _, name := range names {
+		if g.Node(name) == nil {
+			return fmt.Errorf("node %q not found", name)
+		}
+	}
+	return nil
+}
+
+// printNodes writes the data of each node on its own line. Set sortNames
+// when the library does not return the nodes in a meaningful order.
+func printNodes(w io.Writer, nodes []*dag.Node[string], sortNames bool) error {
+	names := make([]string, len(nodes))
+	for i, node := range nodes {
+		names[i] = node.Data()
+	}
+	if sortNames {
+		slices.Sort(names)
+	}
+	for _, name := range names {
+		if _, err := fmt.Fprintln(w, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testEdges = `# build graph
+A B
+A -> C
+B D
+C D
+E
+`
+
+func runCommand(t *testing.T, input string, args ...string) (string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	err := run(args, strings.NewReader(input), &stdout, &stderr)
+	return stdout.String(), err
+}
+
+func TestRunCommands(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"toposort"}, "A\nE\nB\nC\nD\n"},
+		{[]string{"levels"}, "A E\nB C\nD\n"},
+		{[]string{"roots"}, "A\nE\n"},
+		{[]string{"leaves"}, "D\nE\n"},
+		{[]string{"ancestors", "D"}, "A\nB\nC\n"},
+		{[]string{"descendants", "A"}, "B\nC\nD\n"},
+		{[]string{"path", "A", "D"}, "A\nB\nD\n"},
+		{[]string{"has-path", "A", "D"}, "true\n"},
+		{[]string{"range", "D ^B"}, "C\nD\n"},
+		{[]string{"range", "B...C"}, "B\nC\n"},
+		{[]string{"tree"}, "A\n├── B\n│   └── D\n└── C\n    └── D (*)\nE\n"},
+		{[]string{"tree", "C"}, "C\n└── D\n"},
+	}
+
+	for _, tt := range tests {
+		out, err := runCommand(t, testEdges, tt.args...)
+		assert.NoError(t, err, "Unexpected error for %v", tt.args)
+		assert.Equal(t, tt.expected, out, "Unexpected output for %v", tt.args)
+	}
+
+	out, err := runCommand(t, testEdges, "dot")
+	assert.NoError(t, err)
+	assert.Contains(t, out, `"A" -> "B";`)
+
+	// A missing node is an error rather than "false"
+	out, err = runCommand(t, testEdges, "has-path", "A", "X")
+	assert.ErrorContains(t, err, `node "X" not found`)
+	assert.NotErrorIs(t, err, errNoPath)
+	assert.Equal(t, "", out)
+}
+
+func TestRunHasPathFalse(t *testing.T) {
+	out, err := runCommand(t, testEdges, "has-path", "D", "A")
+	assert.ErrorIs(t, err, errNoPath)
+	assert.Equal(t, "false\n", out)
+}
+
+func TestRunErrors(t *testing.T) {
+	_, err := runCommand(t, testEdges, "bogus")
+	assert.ErrorContains(t, err, `unknown command "bogus"`)
+
+	_, err = runCommand(t, testEdges, "path", "A")
+	assert.ErrorContains(t, err, "usage: dag path <from> <to>")
+
+	_, err = runCommand(t, testEdges, "tree", "A", "B")
+	assert.ErrorContains(t, err, "usage: dag tree [<node>]")
+
+	_, err = runCommand(t, testEdges, "range", "A..")
+	assert.ErrorContains(t, err, `expected A..B`)
+
+	_, err = runCommand(t, testEdges, "range", "Z..A")
+	assert.ErrorContains(t, err, `node "Z" not found`)
+
+	_, err = runCommand(t, testEdges, "ancestors", "Z")
+	assert.ErrorContains(t, err, `node "Z" not found`)
+
+	_, err = runCommand(t, testEdges, "path", "D", "A")
+	assert.ErrorContains(t, err, `no path from "D" to "A"`)
+
+	_, err = runCommand(t, "A B\nB A\n", "toposort")
+	assert.ErrorContains(t, err, "line 2: adding this edge would create a cycle")
+
+	_, err = runCommand(t, "A B C\n", "toposort")
+	assert.ErrorContains(t, err, "line 1: expected")
+
+	_, err = runCommand(t, testEdges)
+	assert.Error(t, err, "Expected an error without a command")
+}
+
+func TestRunFormats(t *testing.T) {
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "graph.json")
+	assert.NoError(t, os.WriteFile(jsonPath, []byte(`{"nodes":["A","B","C"],"edges":[{"from":0,"to":1},{"from":1,"to":2}]}`), 0o644))
+	out, err := runCommand(t, "", "-in", jsonPath, "path", "A", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, "A\nB\nC\n", out)
+
+	dotPath := filepath.Join(dir, "graph.dot")
+	assert.NoError(t, os.WriteFile(dotPath, []byte(`digraph { "A" -> "B" -> "C" }`), 0o644))
+	out, err = runCommand(t, "", "-in", dotPath, "descendants", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, "B\nC\n", out)
+
+	// Explicit formats override detection
+	out, err = runCommand(t, "digraph { X -> Y }", "-format", "dot", "roots")
+	assert.NoError(t, err)
+	assert.Equal(t, "X\n", out)
+
+	_, err = runCommand(t, "", "-format", "yaml", "roots")
+	assert.ErrorContains(t, err, `unknown format "yaml"`)
+}
@@ -0,0 +1,411 @@
+package dag
+
+import "slices"
+
+// CompiledDAG is a read-only, memory-compact form of a DAG for large graphs.
+// Nodes are numbered with dense integer IDs and adjacency is stored in
+// compressed sparse row (CSR) form, so queries allocate a few flat slices
+// and bitsets rather than per-node maps.
+//
+// Node IDs follow the node order of the DAG it was compiled from, and every
+// query returns nodes in the same order as the equivalent DAG method.
+type CompiledDAG[T comparable] struct {
+	data []T
+	ids  map[T]int
+
+	// The children of node i are children[childIndex[i]:childIndex[i+1]],
+	// sorted by ID, with the weight of each edge in weights.
+	childIndex []int
+	children   []int
+	weights    []float64
+
+	// The parents of node i are parents[parentIndex[i]:parentIndex[i+1]],
+	// sorted by ID.
+	parentIndex []int
+	parents     []int
+}
+
+// Compile returns a CompiledDAG snapshot of the DAG. Later changes to the
+// DAG are not reflected in the snapshot.
+func (d *DAG[T]) Compile() *CompiledDAG[T] {
+	nodes := d.Nodes()
+	ids := make(map[T]int, len(nodes))
+	for i, node := range nodes {
+		ids[node.data] = i
+	}
+
+	var edges [][2]int
+	var weights []float64
+	for i, node := range nodes {
+		for child, weight := range node.children {
+			edges = append(edges, [2]int{i, ids[child.data]})
+			weights = append(weights, weight)
+		}
+	}
+	return newCompiledDAG(nodeData(nodes), ids, edges, weights)
+}
+
+// NewCompiledDAG builds a CompiledDAG directly from a list of nodes and
+// edges, numbering nodes in the order given and then in the order they first
+// appear in edges. The edges are sorted once and then checked for cycles in a
+// single pass, so this is faster than building a DAG edge by edge. It returns
+// a *CycleError if the edges contain a cycle.
+func NewCompiledDAG[T comparable](nodes []T, edges [][2]T) (*CompiledDAG[T], error) {
+	ids := make(map[T]int, len(nodes))
+	var data []T
+	id := func(v T) int {
+		i, exists := ids[v]
+		if !exists {
+			i = len(data)
+			ids[v] = i
+			data = append(data, v)
+		}
+		return i
+	}
+	for _, v := range nodes {
+		id(v)
+	}
+
+	indexed := make([][2]int, len(edges))
+	weights := make([]float64, len(edges))
+	for i, edge := range edges {
+		indexed[i] = [2]int{id(edge[0]), id(edge[1])}
+		weights[i] = DefaultEdgeWeight
+	}
+
+	c := newCompiledDAG(data, ids, indexed, weights)
+	if cycle := c.findCycle(); cycle != nil {
+		path := make([]T, len(cycle))
+		for i, v := range cycle {
+			path[i] = c.data[v]
+		}
+		return nil, &CycleError[T]{From: path[len(path)-1], To: path[0], Path: path}
+	}
+	return c, nil
+}
+
+// newCompiledDAG lays out the given edges in CSR form, dropping duplicates.
+func newCompiledDAG[T comparable](data []T, ids map[T]int, edges [][2]int, weights []float64) *CompiledDAG[T] {
+	n := len(data)
+	order := make([]int, len(edges))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		if edges[a][0] != edges[b][0] {
+			return edges[a][0] - edges[b][0]
+		}
+		return edges[a][1] - edges[b][1]
+	})
+
+	c := &CompiledDAG[T]{
+		data:        data,
+		ids:         ids,
+		childIndex:  make([]int, n+1),
+		parentIndex: make([]int, n+1),
+	}
+	for i, e := range order {
+		edge := edges[e]
+		if i > 0 && edge == edges[order[i-1]] {
+			continue
+		}
+		c.children = append(c.children, edge[1])
+		c.weights = append(c.weights, weights[e])
+		c.childIndex[edge[0]+1]++
+		c.parentIndex[edge[1]+1]++
+	}
+	for i := 0; i < n; i++ {
+		c.childIndex[i+1] += c.childIndex[i]
+		c.parentIndex[i+1] += c.parentIndex[i]
+	}
+
+	// Edges are sorted by source then target, so filling parents in edge
+	// order leaves each parent list sorted by ID.
+	c.parents = make([]int, len(c.children))
+	next := slices.Clone(c.parentIndex[:n])
+	for from := 0; from < n; from++ {
+		for _, to := range c.childrenOf(from) {
+			c.parents[next[to]] = from
+			next[to]++
+		}
+	}
+	return c
+}
+
+func (c *CompiledDAG[T]) childrenOf(id int) []int {
+	return c.children[c.childIndex[id]:c.childIndex[id+1]]
+}
+
+func (c *CompiledDAG[T]) parentsOf(id int) []int {
+	return c.parents[c.parentIndex[id]:c.parentIndex[id+1]]
+}
+
+// findCycle returns the IDs of the nodes on a cycle, in edge order,
+// or nil if there is none.
+func (c *CompiledDAG[T]) findCycle() []int {
+	const (
+		unvisited = iota
+		active
+		done
+	)
+	state := make([]uint8, len(c.data))
+	parent := make([]int, len(c.data))
+
+	type frame struct{ id, next int }
+	for start := range c.data {
+		if state[start] != unvisited {
+			continue
+		}
+		stack := []frame{{id: start}}
+		state[start] = active
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			children := c.childrenOf(top.id)
+			if top.next == len(children) {
+				state[top.id] = done
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			child := children[top.next]
+			top.next++
+			switch state[child] {
+			case unvisited:
+				state[child] = active
+				parent[child] = top.id
+				stack = append(stack, frame{id: child})
+			case active:
+				// Found a back edge; unwind the active path to build the cycle
+				cycle := []int{top.id}
+				for v := top.id; v != child; {
+					v = parent[v]
+					cycle = append(cycle, v)
+				}
+				slices.Reverse(cycle)
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// Len returns the number of nodes.
+func (c *CompiledDAG[T]) Len() int {
+	return len(c.data)
+}
+
+// ID returns the integer ID of the node with the given data, and whether
+// it exists.
+func (c *CompiledDAG[T]) ID(data T) (int, bool) {
+	id, exists := c.ids[data]
+	return id, exists
+}
+
+// Data returns the data of the node with the given ID.
+func (c *CompiledDAG[T]) Data(id int) T {
+	return c.data[id]
+}
+
+// Nodes returns the data of all nodes, in ID order.
+func (c *CompiledDAG[T]) Nodes() []T {
+	return slices.Clone(c.data)
+}
+
+// Children returns the data of the children of the node with the given data.
+func (c *CompiledDAG[T]) Children(data T) []T {
+	id, exists := c.ids[data]
+	if !exists {
+		return nil
+	}
+	return c.lookup(c.childrenOf(id))
+}
+
+// Parents returns the data of the parents of the node with the given data.
+func (c *CompiledDAG[T]) Parents(data T) []T {
+	id, exists := c.ids[data]
+	if !exists {
+		return nil
+	}
+	return c.lookup(c.parentsOf(id))
+}
+
+// EdgeWeight returns the weight of the edge from the node with data 'from'
+// to the node with data 'to', and whether the edge exists.
+func (c *CompiledDAG[T]) EdgeWeight(from, to T) (float64, bool) {
+	fromID, fromExists := c.ids[from]
+	toID, toExists := c.ids[to]
+	if !fromExists || !toExists {
+		return 0, false
+	}
+	children := c.childrenOf(fromID)
+	if i, found := slices.BinarySearch(children, toID); found {
+		return c.weights[c.childIndex[fromID]+i], true
+	}
+	return 0, false
+}
+
+// Roots returns the data of all nodes with no parents.
+func (c *CompiledDAG[T]) Roots() []T {
+	var roots []T
+	for id, v := range c.data {
+		if c.parentIndex[id] == c.parentIndex[id+1] {
+			roots = append(roots, v)
+		}
+	}
+	return roots
+}
+
+// Leaves returns the data of all nodes with no children.
+func (c *CompiledDAG[T]) Leaves() []T {
+	var leaves []T
+	for id, v := range c.data {
+		if c.childIndex[id] == c.childIndex[id+1] {
+			leaves = append(leaves, v)
+		}
+	}
+	return leaves
+}
+
+// Traverse returns the data of all nodes in topological order.
+func (c *CompiledDAG[T]) Traverse() []T {
+	return c.lookup(c.topoOrder())
+}
+
+// topoOrder returns the IDs of all nodes in topological order.
+func (c *CompiledDAG[T]) topoOrder() []int {
+	n := len(c.data)
+	inDegree := make([]int, n)
+	queue := make([]int, 0, n)
+	for id := 0; id < n; id++ {
+		inDegree[id] = c.parentIndex[id+1] - c.parentIndex[id]
+		if inDegree[id] == 0 {
+			queue = append(queue, id)
+		}
+	}
+	for i := 0; i < len(queue); i++ {
+		for _, child := range c.childrenOf(queue[i]) {
+			inDegree[child]--
+			if inDegree[child] == 0 {
+				queue = append(queue, child)
+			}
+		}
+	}
+	return queue
+}
+
+// Descendants returns the data of all descendants of the node with the
+// given data, in depth-first order.
+func (c *CompiledDAG[T]) Descendants(data T) []T {
+	id, exists := c.ids[data]
+	if !exists {
+		return nil
+	}
+	return c.lookup(c.reach(id, c.childrenOf))
+}
+
+// Ancestors returns the data of all ancestors of the node with the given
+// data, in depth-first order.
+func (c *CompiledDAG[T]) Ancestors(data T) []T {
+	id, exists := c.ids[data]
+	if !exists {
+		return nil
+	}
+	return c.lookup(c.reach(id, c.parentsOf))
+}
+
+// HasPath checks if there is a path from the node with data 'from'
+// to the node with data 'to'.
+func (c *CompiledDAG[T]) HasPath(from, to T) bool {
+	fromID, fromExists := c.ids[from]
+	toID, toExists := c.ids[to]
+	if !fromExists || !toExists {
+		return false
+	}
+	if fromID == toID {
+		return true
+	}
+
+	visited := newBitset(len(c.data))
+	visited.set(fromID)
+	stack := []int{fromID}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, child := range c.childrenOf(id) {
+			if child == toID {
+				return true
+			}
+			if !visited.has(child) {
+				visited.set(child)
+				stack = append(stack, child)
+			}
+		}
+	}
+	return false
+}
+
+// reach returns the IDs reachable from start (excluding start) in
+// depth-first pre-order, using next to find the neighbours of each node.
+func (c *CompiledDAG[T]) reach(start int, next func(id int) []int) []int {
+	type frame struct{ id, next int }
+
+	var reached []int
+	visited := newBitset(len(c.data))
+	stack := []frame{{id: start}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		neighbours := next(top.id)
+		if top.next == len(neighbours) {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		neighbour := neighbours[top.next]
+		top.next++
+		if !visited.has(neighbour) {
+			visited.set(neighbour)
+			reached = append(reached, neighbour)
+			stack = append(stack, frame{id: neighbour})
+		}
+	}
+	return reached
+}
+
+// lookup maps node IDs to their data.
+func (c *CompiledDAG[T]) lookup(ids []int) []T {
+	if len(ids) == 0 {
+		return nil
+	}
+	data := make([]T, len(ids))
+	for i, id := range ids {
+		data[i] = c.data[id]
+	}
+	return data
+}
+
+// bitset is a fixed-size set of small non-negative integers.
+type bitset []uint64
+
+func newBitset(size int) bitset {
+	return make(bitset, (size+63)/64)
+}
+
+func (b bitset) set(i int) {
+	b[i/64] |= 1 << (uint(i) % 64)
+}
+
+func (b bitset) has(i int) bool {
+	return b[i/64]&(1<<(uint(i)%64)) != 0
+}
+
+// or adds every member of other to b.
+func (b bitset) or(other bitset) {
+	for i := range b {
+		b[i] |= other[i]
+	}
+}
+
+// and removes every member of b that is not in other.
+func (b bitset) and(other bitset) {
+	for i := range b {
+		b[i] &= other[i]
+	}
+}
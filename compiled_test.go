@@ -0,0 +1,156 @@
+package dag
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertSameNodes asserts that two node lists are equal and in the same
+// order, treating nil and empty lists as equal.
+func assertSameNodes[T comparable](t *testing.T, expected, actual []T, msgAndArgs ...any) {
+	t.Helper()
+	if len(expected) == 0 {
+		assert.Empty(t, actual, msgAndArgs...)
+		return
+	}
+	assert.Equal(t, expected, actual, msgAndArgs...)
+}
+
+func TestCompile(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("A", "C")
+	dag.AddEdge("B", "D")
+	dag.AddWeightedEdge("C", "D", 2)
+	dag.AddEdge("D", "E")
+	dag.AddNode("F")
+
+	compiled := dag.Compile()
+
+	assert.Equal(t, 6, compiled.Len())
+	assert.Equal(t, nodeData(dag.Nodes()), compiled.Nodes())
+	assert.Equal(t, nodeData(dag.Roots()), compiled.Roots())
+	assert.Equal(t, nodeData(dag.Leaves()), compiled.Leaves())
+
+	sorted, _ := dag.Traverse()
+	assert.Equal(t, nodeData(sorted), compiled.Traverse())
+
+	for _, v := range []string{"A", "B", "C", "D", "E", "F"} {
+		assertSameNodes(t, nodeData(dag.Node(v).Children()), compiled.Children(v), "Unexpected children of %s", v)
+		assertSameNodes(t, nodeData(dag.Node(v).Parents()), compiled.Parents(v), "Unexpected parents of %s", v)
+		assertSameNodes(t, nodeData(dag.Descendants(v)), compiled.Descendants(v), "Unexpected descendants of %s", v)
+		assertSameNodes(t, nodeData(dag.Ancestors(v)), compiled.Ancestors(v), "Unexpected ancestors of %s", v)
+		for _, w := range []string{"A", "B", "C", "D", "E", "F"} {
+			assert.Equal(t, dag.HasPath(v, w), compiled.HasPath(v, w), "Unexpected HasPath(%s, %s)", v, w)
+		}
+	}
+
+	weight, exists := compiled.EdgeWeight("C", "D")
+	assert.True(t, exists)
+	assert.Equal(t, 2.0, weight)
+	_, exists = compiled.EdgeWeight("A", "D")
+	assert.False(t, exists)
+
+	id, exists := compiled.ID("C")
+	assert.True(t, exists)
+	assert.Equal(t, "C", compiled.Data(id))
+
+	assert.Nil(t, compiled.Descendants("Z"))
+	assert.False(t, compiled.HasPath("A", "Z"))
+
+	// The snapshot is not affected by later changes
+	dag.AddEdge("E", "F")
+	assert.Nil(t, compiled.Children("E"))
+}
+
+func TestNewCompiledDAG(t *testing.T) {
+	compiled, err := NewCompiledDAG([]int{0}, [][2]int{{1, 2}, {2, 3}, {1, 3}, {1, 2}})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []int{0, 1, 2, 3}, compiled.Nodes())
+	assert.Equal(t, []int{2, 3}, compiled.Children(1), "Expected duplicate edges to be dropped")
+	assert.Equal(t, []int{0, 1, 2, 3}, compiled.Traverse())
+	assert.Equal(t, []int{1, 2}, compiled.Ancestors(3))
+}
+
+func TestNewCompiledDAGCycle(t *testing.T) {
+	_, err := NewCompiledDAG(nil, [][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}})
+	assert.ErrorIs(t, err, ErrCycleDetected)
+
+	var cycleErr *CycleError[string]
+	assert.True(t, errors.As(err, &cycleErr), "Expected a *CycleError")
+	assert.Equal(t, []string{"c", "a", "b", "c"}, cycleErr.Cycle())
+
+	_, err = NewCompiledDAG(nil, [][2]string{{"a", "a"}})
+	assert.True(t, errors.As(err, &cycleErr), "Expected a *CycleError for a self-loop")
+	assert.Equal(t, []string{"a", "a"}, cycleErr.Cycle())
+}
+
+// benchmarkEdges returns the edges of a random layered DAG where each node
+// has up to four edges to nodes with higher numbers.
+func benchmarkEdges(n int) [][2]int {
+	r := rand.New(rand.NewSource(1))
+	var edges [][2]int
+	for from := 0; from < n-1; from++ {
+		for i := 0; i < 4; i++ {
+			to := from + 1 + r.Intn(min(n-from-1, 100))
+			edges = append(edges, [2]int{from, to})
+		}
+	}
+	return edges
+}
+
+const benchmarkNodes = 1000
+
+func benchmarkDAG(edges [][2]int) *DAG[int] {
+	dag := NewDAG(WithOrdered[int]())
+	for _, edge := range edges {
+		dag.AddEdge(edge[0], edge[1])
+	}
+	return dag
+}
+
+func BenchmarkDAGAddEdge(b *testing.B) {
+	edges := benchmarkEdges(benchmarkNodes)
+	for b.Loop() {
+		benchmarkDAG(edges)
+	}
+}
+
+func BenchmarkNewCompiledDAG(b *testing.B) {
+	edges := benchmarkEdges(benchmarkNodes)
+	for b.Loop() {
+		NewCompiledDAG(nil, edges)
+	}
+}
+
+func BenchmarkDAGTraverse(b *testing.B) {
+	dag := benchmarkDAG(benchmarkEdges(benchmarkNodes))
+	for b.Loop() {
+		dag.Traverse()
+	}
+}
+
+func BenchmarkCompiledTraverse(b *testing.B) {
+	compiled := benchmarkDAG(benchmarkEdges(benchmarkNodes)).Compile()
+	for b.Loop() {
+		compiled.Traverse()
+	}
+}
+
+func BenchmarkDAGDescendants(b *testing.B) {
+	dag := benchmarkDAG(benchmarkEdges(benchmarkNodes))
+	for b.Loop() {
+		dag.Descendants(0)
+	}
+}
+
+func BenchmarkCompiledDescendants(b *testing.B) {
+	compiled := benchmarkDAG(benchmarkEdges(benchmarkNodes)).Compile()
+	for b.Loop() {
+		compiled.Descendants(0)
+	}
+}
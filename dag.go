@@ -1,20 +1,86 @@
 package dag
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
 
 // ErrCycleDetected is returned when an operation would create a cycle in the DAG.
 var ErrCycleDetected = fmt.Errorf("adding this edge would create a cycle")
 
+// CycleError is returned when adding the edge From -> To would create a cycle.
+// It matches ErrCycleDetected with errors.Is.
+type CycleError[T comparable] struct {
+	// From and To are the endpoints of the rejected edge.
+	From, To T
+	// Path is the existing path from To back to From, inclusive of both ends.
+	Path []T
+}
+
+// Cycle returns the nodes forming the cycle, starting and ending with From.
+func (e *CycleError[T]) Cycle() []T {
+	cycle := make([]T, 0, len(e.Path)+1)
+	cycle = append(cycle, e.From)
+	return append(cycle, e.Path...)
+}
+
+func (e *CycleError[T]) Error() string {
+	parts := make([]string, 0, len(e.Path)+1)
+	for _, v := range e.Cycle() {
+		parts = append(parts, fmt.Sprintf("%v", v))
+	}
+	return fmt.Sprintf("%v: %s", ErrCycleDetected, strings.Join(parts, " -> "))
+}
+
+// Is reports whether target is ErrCycleDetected.
+func (e *CycleError[T]) Is(target error) bool {
+	return target == ErrCycleDetected
+}
+
 // DAG represents a directed acyclic graph.
 type DAG[T comparable] struct {
 	nodes map[T]*Node[T]
+
+	// order determines the order of nodes returned by queries and
+	// traversals, and nextSeq numbers nodes as they are added.
+	order   *ordering[T]
+	nextSeq uint64
+
+	// observers are notified of mutations. While a transaction is open,
+	// events are held in pending until it commits.
+	observers []observer[T]
+	nextObsID int
+	pending   *[]Event[T]
+
+	// reachIndexed enables the reachability index, which is built into
+	// reach on demand, under reachMu, and discarded or updated on mutation.
+	reachIndexed bool
+	reachMu      sync.Mutex
+	reach        *reachIndex[T]
 }
 
-// NewDAG creates and returns a new empty DAG.
-func NewDAG[T comparable]() *DAG[T] {
-	return &DAG[T]{
+// NewDAG creates and returns a new empty DAG. By default, nodes are ordered
+// by the string representation of their data; pass WithCompare, WithOrdered
+// or WithInsertionOrder to change this.
+func NewDAG[T comparable](opts ...Option[T]) *DAG[T] {
+	d := &DAG[T]{
 		nodes: make(map[T]*Node[T]),
 	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// emptyCopy returns a new empty DAG with the same ordering as this one.
+func (d *DAG[T]) emptyCopy() *DAG[T] {
+	return &DAG[T]{
+		nodes:        make(map[T]*Node[T]),
+		order:        d.order,
+		reachIndexed: d.reachIndexed,
+	}
 }
 
 // AddNode adds a node with the given data to the DAG.
@@ -24,26 +90,50 @@ func (d *DAG[T]) AddNode(data T) *Node[T] {
 		return node
 	}
 	node := NewNode(data)
+	node.order = d.order
+	node.seq = d.nextSeq
+	// A new node has no edges, so it can go last in the topological order
+	node.ord = d.nextSeq
+	d.nextSeq++
 	d.nodes[data] = node
+	d.invalidateReach()
+	d.emit(Event[T]{Type: NodeAdded, Node: data})
 	return node
 }
 
 // AddEdge adds a directed edge from the node with data 'from' to the node with data 'to'.
-// It returns an error if adding the edge would create a cycle.
+// It returns a *CycleError if adding the edge would create a cycle.
 func (d *DAG[T]) AddEdge(from, to T) error {
 	fromNode := d.AddNode(from)
 	toNode := d.AddNode(to)
 
-	// Check for cycles
-	if fromNode.hasAncestor(toNode) {
-		return ErrCycleDetected
+	if _, exists := fromNode.children[toNode]; exists {
+		return nil
+	}
+
+	// Check for cycles, only searching the part of the graph whose
+	// topological order is affected by the new edge
+	if !d.reorderForEdge(fromNode, toNode) {
+		return newCycleError(from, to, fromNode.pathFromAncestor(toNode))
 	}
 
 	fromNode.addChild(toNode)
 	toNode.addParent(fromNode)
+	if d.reach != nil && !d.reach.addEdge(fromNode, toNode) {
+		d.invalidateReach()
+	}
+	d.emit(Event[T]{Type: EdgeAdded, From: from, To: to})
 	return nil
 }
 
+func newCycleError[T comparable](from, to T, path []*Node[T]) *CycleError[T] {
+	err := &CycleError[T]{From: from, To: to, Path: make([]T, len(path))}
+	for i, node := range path {
+		err.Path[i] = node.data
+	}
+	return err
+}
+
 // RemoveNode removes the node with the given data from the DAG.
 func (d *DAG[T]) RemoveNode(data T) {
 	node, exists := d.nodes[data]
@@ -51,9 +141,20 @@ func (d *DAG[T]) RemoveNode(data T) {
 		return
 	}
 
+	if d.observed() {
+		// Use deterministic iteration order
+		for _, parent := range node.Parents() {
+			d.emit(Event[T]{Type: EdgeRemoved, From: parent.data, To: data})
+		}
+		for _, child := range node.Children() {
+			d.emit(Event[T]{Type: EdgeRemoved, From: data, To: child.data})
+		}
+	}
+
 	// Remove this node from its parents' children
 	for parent := range node.parents {
 		delete(parent.children, node)
+		delete(parent.edgeAttrs, node)
 	}
 
 	// Remove this node from its children's parents
@@ -62,6 +163,8 @@ func (d *DAG[T]) RemoveNode(data T) {
 	}
 
 	delete(d.nodes, data)
+	d.invalidateReach()
+	d.emit(Event[T]{Type: NodeRemoved, Node: data})
 }
 
 // RemoveEdge removes the directed edge from the node with data 'from' to the node with data 'to'.
@@ -71,29 +174,38 @@ func (d *DAG[T]) RemoveEdge(from, to T) {
 	if !fromExists || !toExists {
 		return
 	}
+	if _, exists := fromNode.children[toNode]; !exists {
+		return
+	}
 
 	delete(fromNode.children, toNode)
+	delete(fromNode.edgeAttrs, toNode)
 	delete(toNode.parents, fromNode)
+	d.invalidateReach()
+	d.emit(Event[T]{Type: EdgeRemoved, From: from, To: to})
 }
 
 // Clear removes all nodes and edges from the DAG.
 func (d *DAG[T]) Clear() {
 	d.nodes = make(map[T]*Node[T])
+	d.invalidateReach()
+	d.emit(Event[T]{Type: Cleared})
 }
 
-// Nodes returns all nodes in the DAG.
+// Nodes returns all nodes in the DAG in deterministic order.
 func (d *DAG[T]) Nodes() []*Node[T] {
 	nodes := make([]*Node[T], 0, len(d.nodes))
 	for _, node := range d.nodes {
 		nodes = append(nodes, node)
 	}
+	d.order.sort(nodes)
 	return nodes
 }
 
 // Edges returns all edges in the DAG as pairs of nodes.
 func (d *DAG[T]) Edges() [][2]*Node[T] {
 	var edges [][2]*Node[T]
-	for _, node := range d.nodes {
+	for _, node := range d.Nodes() {
 		// Use deterministic iteration order
 		for _, child := range node.Children() {
 			edges = append(edges, [2]*Node[T]{node, child})
@@ -102,153 +214,57 @@ func (d *DAG[T]) Edges() [][2]*Node[T] {
 	return edges
 }
 
-// Traverse performs a topological sort of the DAG and returns the nodes in sorted order.
-func (d *DAG[T]) Traverse() ([]*Node[T], error) {
-	inDegree := make(map[*Node[T]]int)
+// inDegrees returns the number of parents of every node in the DAG,
+// keyed by node. It is the starting state for Kahn-style traversals.
+func (d *DAG[T]) inDegrees() map[*Node[T]]int {
+	inDegree := make(map[*Node[T]]int, len(d.nodes))
 	for _, node := range d.nodes {
 		inDegree[node] = len(node.parents)
 	}
+	return inDegree
+}
 
-	var queue []*Node[T]
-	for node, degree := range inDegree {
-		if degree == 0 {
-			queue = append(queue, node)
-		}
-	}
-
-	var sorted []*Node[T]
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-		sorted = append(sorted, current)
-
-		// Use deterministic iteration order
-		for _, child := range current.Children() {
-			inDegree[child]--
-			if inDegree[child] == 0 {
-				queue = append(queue, child)
-			}
-		}
-	}
-
-	return sorted, nil
+// Traverse performs a topological sort of the DAG and returns the nodes in sorted order.
+func (d *DAG[T]) Traverse() ([]*Node[T], error) {
+	return slices.Collect(d.Topological()), nil
 }
 
 // Walk performs a depth-first traversal starting from the node with the given data.
 func (d *DAG[T]) Walk(start T, visit func(v T)) {
-	startNode := d.nodes[start]
-	if startNode == nil {
-		return
-	}
-
-	visited := make(map[*Node[T]]struct{})
-	var walk func(node *Node[T])
-	walk = func(node *Node[T]) {
-		if _, seen := visited[node]; seen {
-			return
-		}
-		visited[node] = struct{}{}
+	for node := range d.DFS(start) {
 		visit(node.Data())
-		// Use deterministic iteration order
-		for _, child := range node.Children() {
-			walk(child)
-		}
 	}
-	walk(startNode)
 }
 
 // ReverseWalk performs a depth-first traversal in reverse
 // starting from the node with the given data.
 func (d *DAG[T]) ReverseWalk(start T, visit func(v T)) {
-	startNode := d.nodes[start]
-	if startNode == nil {
-		return
-	}
-
-	visited := make(map[*Node[T]]struct{})
-	var walk func(node *Node[T])
-	walk = func(node *Node[T]) {
-		if _, seen := visited[node]; seen {
-			return
-		}
-		visited[node] = struct{}{}
+	for node := range d.ReverseDFS(start) {
 		visit(node.Data())
-		// Use deterministic iteration order
-		for _, parent := range node.Parents() {
-			walk(parent)
-		}
 	}
-	walk(startNode)
 }
 
 // BreadthFirstWalk performs a breadth-first traversal starting from the node with the given data.
 func (d *DAG[T]) BreadthFirstWalk(start T, visit func(v T)) {
-	startNode := d.nodes[start]
-	if startNode == nil {
-		return
-	}
-
-	visited := make(map[*Node[T]]struct{})
-	queue := []*Node[T]{startNode}
-	visited[startNode] = struct{}{}
-
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-		visit(current.Data())
-
-		// Use deterministic iteration order
-		for _, child := range current.Children() {
-			if _, seen := visited[child]; !seen {
-				visited[child] = struct{}{}
-				queue = append(queue, child)
-			}
-		}
+	for node := range d.BFS(start) {
+		visit(node.Data())
 	}
 }
 
 // ReverseBreadthFirstWalk performs a breadth-first traversal in reverse
 // starting from the node with the given data.
 func (d *DAG[T]) ReverseBreadthFirstWalk(start T, visit func(v T)) {
-	startNode := d.nodes[start]
-	if startNode == nil {
-		return
-	}
-
-	visited := make(map[*Node[T]]struct{})
-	queue := []*Node[T]{startNode}
-	visited[startNode] = struct{}{}
-
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-		visit(current.Data())
-
-		// Use deterministic iteration order
-		for _, parent := range current.Parents() {
-			if _, seen := visited[parent]; !seen {
-				visited[parent] = struct{}{}
-				queue = append(queue, parent)
-			}
-		}
+	for node := range d.ReverseBFS(start) {
+		visit(node.Data())
 	}
 }
 
 // LevelOrder returns the nodes of the DAG in level order.
 func (d *DAG[T]) LevelOrder() [][]*Node[T] {
-	inDegree := make(map[*Node[T]]int)
-	for _, node := range d.nodes {
-		inDegree[node] = len(node.parents)
-	}
+	inDegree := d.inDegrees()
 
 	var levels [][]*Node[T]
-	currentLevel := make([]*Node[T], 0)
-
-	for node, degree := range inDegree {
-		if degree == 0 {
-			currentLevel = append(currentLevel, node)
-		}
-	}
+	currentLevel := d.Roots()
 
 	for len(currentLevel) > 0 {
 		levels = append(levels, currentLevel)
@@ -285,10 +301,11 @@ func (d *DAG[T]) Search(predicate func(node *Node[T]) bool) *Node[T] {
 	return nil
 }
 
-// Roots returns all root nodes (nodes with no parents) in the DAG.
+// Roots returns all root nodes (nodes with no parents) in the DAG
+// in deterministic order.
 func (d *DAG[T]) Roots() []*Node[T] {
 	var roots []*Node[T]
-	for _, node := range d.nodes {
+	for _, node := range d.Nodes() {
 		if len(node.parents) == 0 {
 			roots = append(roots, node)
 		}
@@ -296,10 +313,11 @@ func (d *DAG[T]) Roots() []*Node[T] {
 	return roots
 }
 
-// Leaves returns all leaf nodes (nodes with no children) in the DAG.
+// Leaves returns all leaf nodes (nodes with no children) in the DAG
+// in deterministic order.
 func (d *DAG[T]) Leaves() []*Node[T] {
 	var leaves []*Node[T]
-	for _, node := range d.nodes {
+	for _, node := range d.Nodes() {
 		if len(node.children) == 0 {
 			leaves = append(leaves, node)
 		}
@@ -308,12 +326,18 @@ func (d *DAG[T]) Leaves() []*Node[T] {
 }
 
 // Visualize generates a DOT format representation of the DAG for visualization.
+// Node and edge attributes are written as DOT attributes.
 func (d *DAG[T]) Visualize() string {
 	result := "digraph G {\n"
-	for _, node := range d.nodes {
+	for _, node := range d.Nodes() {
+		if node.attrs.Len() > 0 {
+			result += fmt.Sprintf("    %s%s;\n", dotQuote(fmt.Sprint(node.Data())), dotAttrList(&node.attrs))
+		}
+	}
+	for _, node := range d.Nodes() {
 		// Use deterministic iteration order
 		for _, child := range node.Children() {
-			result += fmt.Sprintf("    \"%v\" -> \"%v\";\n", node.Data(), child.Data())
+			result += fmt.Sprintf("    %s -> %s%s;\n", dotQuote(fmt.Sprint(node.Data())), dotQuote(fmt.Sprint(child.Data())), dotAttrList(node.edgeAttrs[child]))
 		}
 	}
 	result += "}\n"
@@ -344,6 +368,9 @@ func (d *DAG[T]) HasPath(from, to T) bool {
 	if fromNode == nil || toNode == nil {
 		return false
 	}
+	if x := d.reachability(); x != nil {
+		return x.hasPath(x.ids[fromNode], x.ids[toNode])
+	}
 
 	visited := make(map[*Node[T]]struct{})
 	var visit func(node *Node[T]) bool
@@ -412,6 +439,9 @@ func (d *DAG[T]) Ancestors(data T) []*Node[T] {
 	if startNode == nil {
 		return nil
 	}
+	if x := d.reachability(); x != nil {
+		return x.reach(x.ids[startNode], x.parents)
+	}
 	var ancestors []*Node[T]
 	visited := make(map[*Node[T]]struct{})
 
@@ -436,6 +466,9 @@ func (d *DAG[T]) Descendants(data T) []*Node[T] {
 	if startNode == nil {
 		return nil
 	}
+	if x := d.reachability(); x != nil {
+		return x.reach(x.ids[startNode], x.children)
+	}
 	var descendants []*Node[T]
 	visited := make(map[*Node[T]]struct{})
 
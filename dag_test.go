@@ -1,6 +1,7 @@
 package dag
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -64,6 +65,31 @@ func TestAddEdge(t *testing.T) {
 	assert.Error(t, err, "Expected error when adding edge that creates a cycle")
 }
 
+func TestAddEdgeCycleError(t *testing.T) {
+	dag := NewDAG[string]()
+
+	dag.AddEdge("a", "b")
+	dag.AddEdge("b", "c")
+
+	err := dag.AddEdge("c", "a")
+	assert.ErrorIs(t, err, ErrCycleDetected, "Expected cycle error to match ErrCycleDetected")
+
+	var cycleErr *CycleError[string]
+	assert.True(t, errors.As(err, &cycleErr), "Expected a *CycleError")
+	assert.Equal(t, "c", cycleErr.From)
+	assert.Equal(t, "a", cycleErr.To)
+	assert.Equal(t, []string{"a", "b", "c"}, cycleErr.Path, "Expected path from 'to' back to 'from'")
+	assert.Equal(t, []string{"c", "a", "b", "c"}, cycleErr.Cycle())
+	assert.Contains(t, err.Error(), "c -> a -> b -> c")
+
+	// Self-loops are reported as a single-node cycle
+	err = dag.AddEdge("a", "a")
+	assert.True(t, errors.As(err, &cycleErr), "Expected a *CycleError for a self-loop")
+	assert.Equal(t, []string{"a", "a"}, cycleErr.Cycle())
+
+	assert.False(t, dag.HasEdge("c", "a"), "Rejected edge should not be added")
+}
+
 func TestRemoveNode(t *testing.T) {
 	dag := NewDAG[*widget]()
 
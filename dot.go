@@ -0,0 +1,517 @@
+package dag
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+	"unicode"
+)
+
+// DOTGraph is the result of parsing a Graphviz DOT document.
+type DOTGraph struct {
+	// Name is the graph ID, if any.
+	Name string
+	// DAG holds the nodes and edges of the document, with node and edge
+	// attributes copied onto them.
+	DAG *DAG[string]
+	// GraphAttrs holds top-level graph attributes.
+	GraphAttrs map[string]string
+	// NodeAttrs holds the attributes of each node, including any inherited
+	// from "node [...]" defaults in scope when the node was first declared.
+	NodeAttrs map[string]map[string]string
+	// EdgeAttrs holds the attributes of each edge, keyed by {from, to}.
+	EdgeAttrs map[[2]string]map[string]string
+}
+
+// ParseDOT reads a Graphviz digraph and builds a DAG from it. Edge chains
+// (a -> b -> c), subgraphs (including as edge endpoints), quoted and HTML IDs,
+// and attribute lists are supported. Ports are accepted and ignored.
+// It returns an error for undirected graphs, syntax errors, and input
+// containing a cycle.
+func ParseDOT(r io.Reader) (*DOTGraph, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := lexDOT(string(src))
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dotParser{
+		tokens: tokens,
+		graph: &DOTGraph{
+			DAG:        NewDAG[string](),
+			GraphAttrs: make(map[string]string),
+			NodeAttrs:  make(map[string]map[string]string),
+			EdgeAttrs:  make(map[[2]string]map[string]string),
+		},
+	}
+	if err := p.parseGraph(); err != nil {
+		return nil, err
+	}
+
+	d := p.graph.DAG
+	for name, attrs := range p.graph.NodeAttrs {
+		for key, value := range attrs {
+			d.Node(name).Attrs().Set(key, value)
+		}
+	}
+	for edge, attrs := range p.graph.EdgeAttrs {
+		for key, value := range attrs {
+			d.EdgeAttrs(edge[0], edge[1]).Set(key, value)
+		}
+	}
+	return p.graph, nil
+}
+
+// dotAttrList formats attributes as a DOT attribute list with a leading
+// space, or returns "" if there are none.
+func dotAttrList(attrs *Attributes) string {
+	if attrs == nil || attrs.Len() == 0 {
+		return ""
+	}
+	var parts []string
+	for key, value := range attrs.All() {
+		parts = append(parts, fmt.Sprintf("%s=%s", dotAttrName(key), dotQuote(value)))
+	}
+	return " [" + strings.Join(parts, ", ") + "]"
+}
+
+// dotAttrName returns the attribute name as is if it is a plain DOT ID,
+// and quoted otherwise.
+func dotAttrName(name string) string {
+	if name == "" || unicode.IsDigit(rune(name[0])) || strings.IndexFunc(name, func(c rune) bool { return !isDOTIDRune(c) }) >= 0 {
+		return dotQuote(name)
+	}
+	return name
+}
+
+// dotQuote returns s as a quoted DOT ID.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+type dotTokenKind int
+
+const (
+	dotEOF dotTokenKind = iota
+	dotID
+	dotPunct
+	dotEdgeOp
+)
+
+type dotToken struct {
+	kind   dotTokenKind
+	text   string
+	quoted bool
+	line   int
+}
+
+// lexDOT splits a DOT document into tokens, discarding comments and
+// preprocessor lines.
+func lexDOT(src string) ([]dotToken, error) {
+	var tokens []dotToken
+	line := 1
+	runes := []rune(src)
+	atLineStart := true
+
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+			atLineStart = true
+			continue
+		case unicode.IsSpace(c):
+			i++
+			continue
+		case c == '#' && atLineStart:
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			continue
+		case c == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			continue
+		case c == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			start := line
+			i += 2
+			for i < len(runes) && !(runes[i] == '*' && i+1 < len(runes) && runes[i+1] == '/') {
+				if runes[i] == '\n' {
+					line++
+				}
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("dot: line %d: unterminated comment", start)
+			}
+			i += 2
+		case c == '-' && i+1 < len(runes) && (runes[i+1] == '>' || runes[i+1] == '-'):
+			tokens = append(tokens, dotToken{kind: dotEdgeOp, text: string(runes[i : i+2]), line: line})
+			i += 2
+		case strings.ContainsRune("{}[];,=:", c):
+			tokens = append(tokens, dotToken{kind: dotPunct, text: string(c), line: line})
+			i++
+		case c == '"':
+			start := line
+			var sb strings.Builder
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				switch {
+				case runes[i] == '\\' && i+1 < len(runes) && (runes[i+1] == '\\' || runes[i+1] == '"'):
+					sb.WriteRune(runes[i+1])
+					i += 2
+				case runes[i] == '\\' && i+1 < len(runes) && runes[i+1] == '\n':
+					// Escaped newlines continue the string
+					line++
+					i += 2
+				default:
+					if runes[i] == '\n' {
+						line++
+					}
+					sb.WriteRune(runes[i])
+					i++
+				}
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("dot: line %d: unterminated string", start)
+			}
+			i++
+			tokens = append(tokens, dotToken{kind: dotID, text: sb.String(), quoted: true, line: start})
+		case c == '<':
+			start := line
+			depth := 0
+			j := i
+			for ; j < len(runes); j++ {
+				if runes[j] == '\n' {
+					line++
+				}
+				if runes[j] == '<' {
+					depth++
+				} else if runes[j] == '>' {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("dot: line %d: unterminated HTML string", start)
+			}
+			tokens = append(tokens, dotToken{kind: dotID, text: string(runes[i+1 : j]), quoted: true, line: start})
+			i = j + 1
+		case isDOTIDRune(c) || c == '-' || c == '.':
+			j := i
+			for j < len(runes) && (isDOTIDRune(runes[j]) || runes[j] == '.' ||
+				(runes[j] == '-' && j == i)) {
+				j++
+			}
+			tokens = append(tokens, dotToken{kind: dotID, text: string(runes[i:j]), line: line})
+			i = j
+		default:
+			return nil, fmt.Errorf("dot: line %d: unexpected character %q", line, c)
+		}
+		atLineStart = false
+	}
+
+	tokens = append(tokens, dotToken{kind: dotEOF, line: line})
+	return tokens, nil
+}
+
+func isDOTIDRune(c rune) bool {
+	return c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c) || c >= 0x80
+}
+
+// dotScope holds the attribute defaults in effect for a graph or subgraph.
+type dotScope struct {
+	nodeDefaults map[string]string
+	edgeDefaults map[string]string
+}
+
+type dotParser struct {
+	tokens []dotToken
+	pos    int
+	graph  *DOTGraph
+}
+
+func (p *dotParser) peek() dotToken {
+	return p.tokens[p.pos]
+}
+
+func (p *dotParser) next() dotToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != dotEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// isKeyword reports whether tok is the given case-insensitive DOT keyword.
+func (tok dotToken) isKeyword(keyword string) bool {
+	return tok.kind == dotID && !tok.quoted && strings.EqualFold(tok.text, keyword)
+}
+
+func (tok dotToken) is(punct string) bool {
+	return tok.kind == dotPunct && tok.text == punct
+}
+
+func (p *dotParser) errorf(tok dotToken, format string, args ...any) error {
+	return fmt.Errorf("dot: line %d: %s", tok.line, fmt.Sprintf(format, args...))
+}
+
+func (p *dotParser) expect(punct string) error {
+	tok := p.next()
+	if !tok.is(punct) {
+		return p.errorf(tok, "expected %q, found %q", punct, tok.text)
+	}
+	return nil
+}
+
+func (p *dotParser) parseGraph() error {
+	tok := p.next()
+	if tok.isKeyword("strict") {
+		tok = p.next()
+	}
+	if tok.isKeyword("graph") {
+		return p.errorf(tok, "undirected graphs are not supported")
+	}
+	if !tok.isKeyword("digraph") {
+		return p.errorf(tok, "expected \"digraph\", found %q", tok.text)
+	}
+	if p.peek().kind == dotID {
+		p.graph.Name = p.next().text
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	scope := &dotScope{
+		nodeDefaults: make(map[string]string),
+		edgeDefaults: make(map[string]string),
+	}
+	if err := p.parseStmtList(scope, nil, true); err != nil {
+		return err
+	}
+	if tok := p.next(); tok.kind != dotEOF {
+		return p.errorf(tok, "unexpected %q after graph", tok.text)
+	}
+	return nil
+}
+
+// parseStmtList parses statements up to and including the closing brace,
+// recording every node mentioned into members.
+func (p *dotParser) parseStmtList(scope *dotScope, members *[]string, topLevel bool) error {
+	for {
+		tok := p.peek()
+		switch {
+		case tok.kind == dotEOF:
+			return p.errorf(tok, "unexpected end of input, expected \"}\"")
+		case tok.is("}"):
+			p.next()
+			return nil
+		case tok.is(";"):
+			p.next()
+			continue
+		}
+		if err := p.parseStmt(scope, members, topLevel); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *dotParser) parseStmt(scope *dotScope, members *[]string, topLevel bool) error {
+	tok := p.peek()
+
+	switch {
+	case tok.isKeyword("graph"):
+		p.next()
+		attrs, err := p.parseAttrLists()
+		if err != nil {
+			return err
+		}
+		if topLevel {
+			maps.Copy(p.graph.GraphAttrs, attrs)
+		}
+		return nil
+	case tok.isKeyword("node"):
+		p.next()
+		attrs, err := p.parseAttrLists()
+		if err != nil {
+			return err
+		}
+		maps.Copy(scope.nodeDefaults, attrs)
+		return nil
+	case tok.isKeyword("edge"):
+		p.next()
+		attrs, err := p.parseAttrLists()
+		if err != nil {
+			return err
+		}
+		maps.Copy(scope.edgeDefaults, attrs)
+		return nil
+	case tok.kind == dotID && p.tokens[p.pos+1].is("="):
+		// ID '=' ID graph attribute
+		p.next()
+		p.next()
+		value := p.next()
+		if value.kind != dotID {
+			return p.errorf(value, "expected attribute value, found %q", value.text)
+		}
+		if topLevel {
+			p.graph.GraphAttrs[tok.text] = value.text
+		}
+		return nil
+	}
+
+	// Node or edge statement, starting with a node ID or subgraph
+	left, err := p.parseEndpoint(scope, members)
+	if err != nil {
+		return err
+	}
+
+	if p.peek().kind != dotEdgeOp {
+		attrs, err := p.parseAttrLists()
+		if err != nil {
+			return err
+		}
+		for _, name := range left {
+			maps.Copy(p.graph.NodeAttrs[name], attrs)
+		}
+		return nil
+	}
+
+	type edgeLine struct {
+		from, to []string
+		line     int
+	}
+	var chain []edgeLine
+	for p.peek().kind == dotEdgeOp {
+		op := p.next()
+		if op.text != "->" {
+			return p.errorf(op, "undirected edge %q in digraph", op.text)
+		}
+		right, err := p.parseEndpoint(scope, members)
+		if err != nil {
+			return err
+		}
+		chain = append(chain, edgeLine{from: left, to: right, line: op.line})
+		left = right
+	}
+
+	attrs, err := p.parseAttrLists()
+	if err != nil {
+		return err
+	}
+	for _, link := range chain {
+		for _, from := range link.from {
+			for _, to := range link.to {
+				if err := p.graph.DAG.AddEdge(from, to); err != nil {
+					return fmt.Errorf("dot: line %d: edge %q -> %q: %w", link.line, from, to, err)
+				}
+				key := [2]string{from, to}
+				edgeAttrs := p.graph.EdgeAttrs[key]
+				if edgeAttrs == nil {
+					edgeAttrs = maps.Clone(scope.edgeDefaults)
+					p.graph.EdgeAttrs[key] = edgeAttrs
+				}
+				maps.Copy(edgeAttrs, attrs)
+			}
+		}
+	}
+	return nil
+}
+
+// parseEndpoint parses a node ID or subgraph and returns the nodes it names.
+func (p *dotParser) parseEndpoint(scope *dotScope, members *[]string) ([]string, error) {
+	tok := p.peek()
+	if tok.isKeyword("subgraph") || tok.is("{") {
+		return p.parseSubgraph(scope, members)
+	}
+
+	tok = p.next()
+	if tok.kind != dotID {
+		return nil, p.errorf(tok, "expected node ID, found %q", tok.text)
+	}
+	// Ports are accepted but not recorded
+	for p.peek().is(":") {
+		p.next()
+		if port := p.next(); port.kind != dotID {
+			return nil, p.errorf(port, "expected port, found %q", port.text)
+		}
+	}
+
+	p.declareNode(tok.text, scope)
+	if members != nil {
+		*members = append(*members, tok.text)
+	}
+	return []string{tok.text}, nil
+}
+
+func (p *dotParser) parseSubgraph(scope *dotScope, members *[]string) ([]string, error) {
+	if p.peek().isKeyword("subgraph") {
+		p.next()
+		if p.peek().kind == dotID {
+			p.next()
+		}
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	inner := &dotScope{
+		nodeDefaults: maps.Clone(scope.nodeDefaults),
+		edgeDefaults: maps.Clone(scope.edgeDefaults),
+	}
+	var names []string
+	if err := p.parseStmtList(inner, &names, false); err != nil {
+		return nil, err
+	}
+	if members != nil {
+		*members = append(*members, names...)
+	}
+	return names, nil
+}
+
+// declareNode adds the node to the DAG if it is new, applying the node
+// defaults currently in scope.
+func (p *dotParser) declareNode(name string, scope *dotScope) {
+	if _, exists := p.graph.NodeAttrs[name]; exists {
+		return
+	}
+	p.graph.DAG.AddNode(name)
+	p.graph.NodeAttrs[name] = maps.Clone(scope.nodeDefaults)
+}
+
+// parseAttrLists parses zero or more bracketed attribute lists.
+func (p *dotParser) parseAttrLists() (map[string]string, error) {
+	attrs := make(map[string]string)
+	for p.peek().is("[") {
+		p.next()
+		for !p.peek().is("]") {
+			key := p.next()
+			if key.kind != dotID {
+				return nil, p.errorf(key, "expected attribute name, found %q", key.text)
+			}
+			value := "true"
+			if p.peek().is("=") {
+				p.next()
+				tok := p.next()
+				if tok.kind != dotID {
+					return nil, p.errorf(tok, "expected attribute value, found %q", tok.text)
+				}
+				value = tok.text
+			}
+			attrs[key.text] = value
+			if p.peek().is(",") || p.peek().is(";") {
+				p.next()
+			}
+		}
+		p.next()
+	}
+	return attrs, nil
+}
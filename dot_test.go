@@ -0,0 +1,121 @@
+package dag
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDOT(t *testing.T) {
+	src := `
+# preprocessor lines are ignored
+strict digraph "deps" {
+	// graph attributes
+	rankdir = LR;
+	graph [label="build"];
+	node [shape=box];
+
+	a -> b -> c [kind=build];
+	"quoted id" -> a;
+	d [color=red, label="D node"];
+	/* block
+	   comment */
+	subgraph cluster_x {
+		node [shape=ellipse];
+		e; f
+	}
+	c -> { e f } [kind=runtime];
+	g:port1 -> <<b>html</b>>;
+	"C:\\dir\\" -> "say \"hi\"";
+}
+`
+	graph, err := ParseDOT(strings.NewReader(src))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "deps", graph.Name)
+	assert.Equal(t, map[string]string{"rankdir": "LR", "label": "build"}, graph.GraphAttrs)
+
+	dag := graph.DAG
+	assert.Len(t, dag.Nodes(), 11, "Expected every declared node in the DAG")
+	assert.True(t, dag.HasEdge("a", "b"))
+	assert.True(t, dag.HasEdge("b", "c"))
+	assert.True(t, dag.HasEdge("quoted id", "a"))
+	assert.True(t, dag.HasEdge("c", "e"))
+	assert.True(t, dag.HasEdge("c", "f"))
+	assert.True(t, dag.HasEdge("g", "<b>html</b>"))
+	assert.True(t, dag.HasEdge(`C:\dir\`, `say "hi"`), "Expected escaped backslashes and quotes to be unescaped")
+	assert.NotNil(t, dag.Node("d"), "Expected isolated node to be parsed")
+
+	assert.Equal(t, map[string]string{"shape": "box", "color": "red", "label": "D node"}, graph.NodeAttrs["d"])
+	assert.Equal(t, "ellipse", graph.NodeAttrs["e"]["shape"], "Expected subgraph node defaults to apply")
+	assert.Equal(t, "box", graph.NodeAttrs["g"]["shape"], "Expected subgraph defaults not to leak")
+	assert.Equal(t, "build", graph.EdgeAttrs[[2]string{"a", "b"}]["kind"])
+	assert.Equal(t, "build", graph.EdgeAttrs[[2]string{"b", "c"}]["kind"])
+	assert.Equal(t, "runtime", graph.EdgeAttrs[[2]string{"c", "f"}]["kind"])
+}
+
+func TestParseDOTVisualizeRoundTrip(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("B", "C")
+	dag.AddEdge("A", "C")
+
+	graph, err := ParseDOT(strings.NewReader(dag.Visualize()))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, nodeData(dag.Nodes()), nodeData(graph.DAG.Nodes()))
+	for _, edge := range dag.Edges() {
+		assert.True(t, graph.DAG.HasEdge(edge[0].Data(), edge[1].Data()))
+	}
+}
+
+func TestParseDOTVisualizeEscaping(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge(`a"b`, `c\`)
+	dag.AddEdge(`c\`, `C:\dir\`)
+	dag.Node(`a"b`).Attrs().Set("label", `say "hi" \`)
+	dag.EdgeAttrs(`a"b`, `c\`).Set("path", `C:\`)
+
+	for _, src := range []string{dag.Visualize(), dag.VisualizeWithOptions(VisualizeOptions[string]{})} {
+		graph, err := ParseDOT(strings.NewReader(src))
+		if !assert.NoError(t, err, "Expected output to parse:\n%s", src) {
+			continue
+		}
+		assert.ElementsMatch(t, nodeData(dag.Nodes()), nodeData(graph.DAG.Nodes()))
+		assert.True(t, graph.DAG.HasEdge(`a"b`, `c\`))
+		assert.True(t, graph.DAG.HasEdge(`c\`, `C:\dir\`))
+		assert.Equal(t, `say "hi" \`, graph.NodeAttrs[`a"b`]["label"])
+		assert.Equal(t, `C:\`, graph.EdgeAttrs[[2]string{`a"b`, `c\`}]["path"])
+	}
+}
+
+func TestParseDOTCycle(t *testing.T) {
+	_, err := ParseDOT(strings.NewReader("digraph {\n a -> b\n b -> c\n c -> a\n}"))
+	assert.ErrorIs(t, err, ErrCycleDetected)
+	assert.ErrorContains(t, err, `line 4: edge "c" -> "a"`)
+
+	var cycleErr *CycleError[string]
+	assert.True(t, errors.As(err, &cycleErr), "Expected a *CycleError")
+	assert.Equal(t, []string{"c", "a", "b", "c"}, cycleErr.Cycle())
+}
+
+func TestParseDOTErrors(t *testing.T) {
+	tests := []struct {
+		src string
+		err string
+	}{
+		{"graph { a -- b }", "undirected graphs are not supported"},
+		{"digraph { a -- b }", "undirected edge"},
+		{"digraph { a -> b", "unexpected end of input"},
+		{`digraph { "a -> b }`, "unterminated string"},
+		{"digraph { a [color=] }", "expected attribute value"},
+		{"digraph { a } b", "after graph"},
+		{"digraph { a @ b }", "unexpected character"},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseDOT(strings.NewReader(tt.src))
+		assert.ErrorContains(t, err, tt.err, "Unexpected error for %q", tt.src)
+	}
+}
@@ -0,0 +1,112 @@
+package dag
+
+import (
+	"fmt"
+	"sync"
+)
+
+// EventType identifies the kind of mutation an Event describes.
+type EventType int
+
+const (
+	// NodeAdded is emitted when a new node is added.
+	NodeAdded EventType = iota + 1
+	// NodeRemoved is emitted when a node is removed, after an EdgeRemoved
+	// event for each of its edges.
+	NodeRemoved
+	// EdgeAdded is emitted when a new edge is added.
+	EdgeAdded
+	// EdgeRemoved is emitted when an edge is removed, either directly or
+	// because one of its nodes was removed.
+	EdgeRemoved
+	// Cleared is emitted when every node and edge is removed at once.
+	Cleared
+)
+
+// String returns the name of the event type.
+func (t EventType) String() string {
+	switch t {
+	case NodeAdded:
+		return "NodeAdded"
+	case NodeRemoved:
+		return "NodeRemoved"
+	case EdgeAdded:
+		return "EdgeAdded"
+	case EdgeRemoved:
+		return "EdgeRemoved"
+	case Cleared:
+		return "Cleared"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(t))
+	}
+}
+
+// Event describes a single mutation of a DAG.
+type Event[T comparable] struct {
+	Type EventType
+	// Node is the data of the node for NodeAdded and NodeRemoved events.
+	Node T
+	// From and To are the data of the edge's nodes for EdgeAdded and
+	// EdgeRemoved events.
+	From, To T
+}
+
+type observer[T comparable] struct {
+	id int
+	fn func(event Event[T])
+}
+
+// Observe registers fn to be called synchronously after every mutation of
+// the DAG, in the order the mutations happen. Mutations made in a transaction
+// are reported when it commits, and not at all if it is rolled back.
+// fn must not mutate the DAG. The returned function unregisters fn.
+func (d *DAG[T]) Observe(fn func(event Event[T])) (cancel func()) {
+	id := d.nextObsID
+	d.nextObsID++
+	d.observers = append(d.observers, observer[T]{id: id, fn: fn})
+	return func() {
+		for i, o := range d.observers {
+			if o.id == id {
+				d.observers = append(d.observers[:i:i], d.observers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Watch returns a channel that receives an Event for every mutation of the
+// DAG, with room for buffer events. Mutations block while the channel is
+// full, so the channel must be drained promptly. The returned function stops
+// the watch and closes the channel; it must not be called concurrently with
+// a mutation.
+func (d *DAG[T]) Watch(buffer int) (<-chan Event[T], func()) {
+	events := make(chan Event[T], buffer)
+	cancel := d.Observe(func(event Event[T]) {
+		events <- event
+	})
+
+	var once sync.Once
+	return events, func() {
+		once.Do(func() {
+			cancel()
+			close(events)
+		})
+	}
+}
+
+// observed reports whether any events are being recorded.
+func (d *DAG[T]) observed() bool {
+	return d.pending != nil || len(d.observers) > 0
+}
+
+// emit delivers an event to every observer, or holds it until the
+// current transaction commits.
+func (d *DAG[T]) emit(event Event[T]) {
+	if d.pending != nil {
+		*d.pending = append(*d.pending, event)
+		return
+	}
+	for _, o := range d.observers {
+		o.fn(event)
+	}
+}
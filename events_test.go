@@ -0,0 +1,139 @@
+package dag
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserve(t *testing.T) {
+	dag := NewDAG[string]()
+
+	var events []Event[string]
+	cancel := dag.Observe(func(event Event[string]) {
+		events = append(events, event)
+	})
+
+	dag.AddEdge("A", "B")
+	dag.AddEdge("B", "C")
+	dag.AddEdge("C", "A")    // rejected, no events
+	dag.AddEdge("A", "B")    // already exists, no events
+	dag.RemoveEdge("A", "C") // does not exist, no events
+	dag.RemoveNode("B")
+	dag.Clear()
+
+	assert.Equal(t, []Event[string]{
+		{Type: NodeAdded, Node: "A"},
+		{Type: NodeAdded, Node: "B"},
+		{Type: EdgeAdded, From: "A", To: "B"},
+		{Type: NodeAdded, Node: "C"},
+		{Type: EdgeAdded, From: "B", To: "C"},
+		{Type: EdgeRemoved, From: "A", To: "B"},
+		{Type: EdgeRemoved, From: "B", To: "C"},
+		{Type: NodeRemoved, Node: "B"},
+		{Type: Cleared},
+	}, events)
+
+	cancel()
+	events = nil
+	dag.AddNode("D")
+	assert.Empty(t, events, "Expected no events after cancelling")
+}
+
+func TestWatch(t *testing.T) {
+	dag := NewDAG[int]()
+
+	events, stop := dag.Watch(4)
+	dag.AddEdge(1, 2)
+	dag.RemoveEdge(1, 2)
+	stop()
+	dag.AddNode(3)
+
+	var received []Event[int]
+	for event := range events {
+		received = append(received, event)
+	}
+	assert.Equal(t, []Event[int]{
+		{Type: NodeAdded, Node: 1},
+		{Type: NodeAdded, Node: 2},
+		{Type: EdgeAdded, From: 1, To: 2},
+		{Type: EdgeRemoved, From: 1, To: 2},
+	}, received)
+
+	// Stopping twice is safe
+	stop()
+}
+
+func TestEventsAddEdges(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+
+	var events []Event[string]
+	dag.Observe(func(event Event[string]) {
+		events = append(events, event)
+	})
+
+	dag.AddEdges([]Edge[string]{{From: "B", To: "C"}, {From: "C", To: "A"}})
+	assert.Equal(t, []Event[string]{
+		{Type: NodeAdded, Node: "C"},
+		{Type: EdgeAdded, From: "B", To: "C"},
+	}, events)
+}
+
+func TestEventsTransaction(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+
+	var events []Event[string]
+	dag.Observe(func(event Event[string]) {
+		events = append(events, event)
+	})
+
+	err := dag.Update(func(tx *Tx[string]) error {
+		tx.AddEdge("B", "C")
+		tx.RemoveNode("A")
+		assert.Empty(t, events, "Expected events to be held until commit")
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []Event[string]{
+		{Type: NodeAdded, Node: "C"},
+		{Type: EdgeAdded, From: "B", To: "C"},
+		{Type: EdgeRemoved, From: "A", To: "B"},
+		{Type: NodeRemoved, Node: "A"},
+	}, events)
+
+	events = nil
+	err = dag.Update(func(tx *Tx[string]) error {
+		tx.AddEdge("C", "D")
+		tx.RemoveEdge("B", "C")
+		return errors.New("abort")
+	})
+	assert.Error(t, err)
+	assert.Empty(t, events, "Expected no events from a rolled back transaction")
+}
+
+func TestEventsUnmarshalJSON(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddNode("Z")
+
+	var events []Event[string]
+	dag.Observe(func(event Event[string]) {
+		events = append(events, event)
+	})
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"nodes":["A","B"],"edges":[{"from":0,"to":1}]}`), dag))
+	assert.Equal(t, []Event[string]{
+		{Type: Cleared},
+		{Type: NodeAdded, Node: "A"},
+		{Type: NodeAdded, Node: "B"},
+		{Type: EdgeAdded, From: "A", To: "B"},
+	}, events)
+}
+
+func TestEventTypeString(t *testing.T) {
+	assert.Equal(t, "EdgeAdded", EdgeAdded.String())
+	assert.Equal(t, "EventType(42)", EventType(42).String())
+}
@@ -0,0 +1,123 @@
+package dag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSkipped is recorded for nodes that were not run because one of their
+// ancestors failed or the execution was stopped early.
+var ErrSkipped = fmt.Errorf("node skipped because a dependency did not complete")
+
+// ExecuteOptions configures Execute.
+type ExecuteOptions struct {
+	// Workers is the maximum number of nodes run concurrently.
+	// A value of zero or less means no limit.
+	Workers int
+
+	// ContinueOnError keeps running nodes that do not depend on a failed node.
+	// By default the first failure cancels the execution.
+	ContinueOnError bool
+}
+
+// Result describes the outcome of running a single node.
+type Result[T comparable] struct {
+	// Data is the data of the node that was run.
+	Data T
+	// Err is the error returned for the node, ErrSkipped if it was never run,
+	// or nil on success.
+	Err error
+	// Duration is how long the node took to run.
+	Duration time.Duration
+}
+
+// Execute runs fn for every node in the DAG, starting each node as soon as
+// all of its parents have finished successfully. It returns a result for every
+// node, along with the first failure (or all failures when
+// ContinueOnError is set). If ctx is cancelled, nodes that have not started
+// are skipped and the context error is returned.
+func Execute[T comparable](ctx context.Context, d *DAG[T], fn func(ctx context.Context, v T) error, opts ExecuteOptions) (map[T]*Result[T], error) {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type completion struct {
+		node     *Node[T]
+		err      error
+		duration time.Duration
+	}
+
+	inDegree := d.inDegrees()
+	results := make(map[T]*Result[T], len(inDegree))
+	blocked := make(map[*Node[T]]struct{})
+	done := make(chan completion)
+
+	ready := d.Roots()
+
+	var errs []error
+	var finish func(node *Node[T], err error, duration time.Duration)
+	finish = func(node *Node[T], err error, duration time.Duration) {
+		results[node.data] = &Result[T]{Data: node.data, Err: err, Duration: duration}
+		// Use deterministic iteration order
+		for _, child := range node.Children() {
+			if err != nil {
+				blocked[child] = struct{}{}
+			}
+			inDegree[child]--
+			if inDegree[child] > 0 {
+				continue
+			}
+			if _, skip := blocked[child]; skip {
+				finish(child, ErrSkipped, 0)
+				continue
+			}
+			ready = append(ready, child)
+		}
+	}
+
+	running := 0
+	for len(ready) > 0 || running > 0 {
+		for len(ready) > 0 && runCtx.Err() == nil && (opts.Workers <= 0 || running < opts.Workers) {
+			node := ready[0]
+			ready = ready[1:]
+			running++
+			go func() {
+				start := time.Now()
+				err := fn(runCtx, node.data)
+				done <- completion{node: node, err: err, duration: time.Since(start)}
+			}()
+		}
+		if running == 0 {
+			// Execution was stopped with nodes still waiting to run.
+			break
+		}
+
+		c := <-done
+		running--
+		if c.err != nil {
+			errs = append(errs, fmt.Errorf("%v: %w", c.node.data, c.err))
+			if !opts.ContinueOnError {
+				cancel()
+			}
+		}
+		finish(c.node, c.err, c.duration)
+	}
+
+	for data := range d.nodes {
+		if _, ok := results[data]; !ok {
+			results[data] = &Result[T]{Data: data, Err: ErrSkipped}
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+	if len(errs) == 0 {
+		return results, nil
+	}
+	if !opts.ContinueOnError {
+		return results, errs[0]
+	}
+	return results, errors.Join(errs...)
+}
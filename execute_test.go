@@ -0,0 +1,120 @@
+package dag
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecute(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("A", "C")
+	dag.AddEdge("B", "D")
+	dag.AddEdge("C", "D")
+
+	var mu sync.Mutex
+	finished := make(map[string]struct{})
+	results, err := Execute(context.Background(), dag, func(ctx context.Context, v string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, parent := range dag.Node(v).Parents() {
+			assert.Contains(t, finished, parent.Data(), "Expected parent %s to finish before %s", parent.Data(), v)
+		}
+		finished[v] = struct{}{}
+		return nil
+	}, ExecuteOptions{})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 4, "Expected a result for every node")
+	for _, v := range []string{"A", "B", "C", "D"} {
+		assert.NoError(t, results[v].Err, "Expected %s to succeed", v)
+	}
+}
+
+func TestExecuteWorkers(t *testing.T) {
+	dag := NewDAG[int]()
+	for i := 1; i <= 10; i++ {
+		dag.AddEdge(0, i)
+	}
+
+	var running, peak int32
+	_, err := Execute(context.Background(), dag, func(ctx context.Context, v int) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	}, ExecuteOptions{Workers: 2})
+
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, peak, int32(2), "Expected at most 2 concurrent workers")
+}
+
+func TestExecuteFailFast(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("B", "C")
+	dag.AddNode("D")
+
+	boom := errors.New("boom")
+	results, err := Execute(context.Background(), dag, func(ctx context.Context, v string) error {
+		if v == "A" {
+			return boom
+		}
+		return nil
+	}, ExecuteOptions{Workers: 1})
+
+	assert.ErrorIs(t, err, boom)
+	assert.ErrorIs(t, results["A"].Err, boom)
+	assert.ErrorIs(t, results["B"].Err, ErrSkipped)
+	assert.ErrorIs(t, results["C"].Err, ErrSkipped)
+	assert.Len(t, results, 4, "Expected a result for every node")
+}
+
+func TestExecuteContinueOnError(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("B", "C")
+	dag.AddEdge("D", "E")
+
+	boom := errors.New("boom")
+	results, err := Execute(context.Background(), dag, func(ctx context.Context, v string) error {
+		if v == "A" {
+			return boom
+		}
+		return nil
+	}, ExecuteOptions{ContinueOnError: true})
+
+	assert.ErrorIs(t, err, boom)
+	assert.ErrorIs(t, results["A"].Err, boom)
+	assert.ErrorIs(t, results["B"].Err, ErrSkipped)
+	assert.ErrorIs(t, results["C"].Err, ErrSkipped)
+	assert.NoError(t, results["D"].Err, "Expected D to run despite A failing")
+	assert.NoError(t, results["E"].Err, "Expected E to run despite A failing")
+}
+
+func TestExecuteCancelled(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results, err := Execute(ctx, dag, func(ctx context.Context, v string) error {
+		cancel()
+		return nil
+	}, ExecuteOptions{})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NoError(t, results["A"].Err)
+	assert.ErrorIs(t, results["B"].Err, ErrSkipped)
+}
@@ -0,0 +1,268 @@
+package dag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VisualizeMermaid generates a Mermaid flowchart of the DAG, listing every
+// node including isolated ones. It accepts the same options as
+// VisualizeWithOptions: the "label", "shape", "color", "fillcolor",
+// "fontcolor", "penwidth" and "style" attributes are translated to Mermaid
+// syntax and others are ignored. Nodes are given generated IDs, so any
+// data can be used safely.
+func (d *DAG[T]) VisualizeMermaid(opts VisualizeOptions[T]) string {
+	s := newStyler(opts)
+	nodes := d.Nodes()
+	ids := exportIDs(nodes)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "flowchart %s\n", mermaidDirection(opts.RankDir))
+
+	var styles []string
+	names, groups := s.clusters(nodes)
+	for i, cluster := range names {
+		indent := "    "
+		if cluster != "" {
+			fmt.Fprintf(&sb, "    subgraph c%d[\"%s\"]\n", i, mermaidEscape(cluster))
+			indent = "        "
+		}
+		for _, node := range groups[cluster] {
+			attrs := s.nodeAttrs(node)
+			left, right := mermaidShape(attrs["shape"])
+			fmt.Fprintf(&sb, "%s%s%s\"%s\"%s\n", indent, ids[node], left, mermaidEscape(exportLabel(node, attrs)), right)
+			if style := mermaidStyle(attrs, true); style != "" {
+				styles = append(styles, fmt.Sprintf("    style %s %s\n", ids[node], style))
+			}
+		}
+		if cluster != "" {
+			sb.WriteString("    end\n")
+		}
+	}
+
+	edge := 0
+	for _, node := range nodes {
+		// Use deterministic iteration order
+		for _, child := range node.Children() {
+			attrs := s.edgeAttrs(node, child)
+			arrow := "-->"
+			if label, exists := attrs["label"]; exists {
+				arrow += fmt.Sprintf("|\"%s\"|", mermaidEscape(label))
+			}
+			fmt.Fprintf(&sb, "    %s %s %s\n", ids[node], arrow, ids[child])
+			if style := mermaidStyle(attrs, false); style != "" {
+				styles = append(styles, fmt.Sprintf("    linkStyle %d %s\n", edge, style))
+			}
+			edge++
+		}
+	}
+
+	for _, style := range styles {
+		sb.WriteString(style)
+	}
+	return sb.String()
+}
+
+// VisualizePlantUML generates a PlantUML component diagram of the DAG,
+// listing every node including isolated ones. It accepts the same options
+// as VisualizeWithOptions: the "label", "shape", "color", "fillcolor",
+// "penwidth" and "style" attributes are translated to PlantUML syntax and
+// others are ignored. Nodes are given generated aliases, so any data can be
+// used safely.
+func (d *DAG[T]) VisualizePlantUML(opts VisualizeOptions[T]) string {
+	s := newStyler(opts)
+	nodes := d.Nodes()
+	ids := exportIDs(nodes)
+
+	var sb strings.Builder
+	sb.WriteString("@startuml\n")
+	if opts.RankDir == "LR" || opts.RankDir == "RL" {
+		sb.WriteString("left to right direction\n")
+	}
+
+	names, groups := s.clusters(nodes)
+	for _, cluster := range names {
+		indent := ""
+		if cluster != "" {
+			fmt.Fprintf(&sb, "package \"%s\" {\n", plantUMLEscape(cluster))
+			indent = "  "
+		}
+		for _, node := range groups[cluster] {
+			attrs := s.nodeAttrs(node)
+			fmt.Fprintf(&sb, "%s%s \"%s\" as %s%s\n", indent, plantUMLShape(attrs["shape"]), plantUMLEscape(exportLabel(node, attrs)), ids[node], plantUMLNodeStyle(attrs))
+		}
+		if cluster != "" {
+			sb.WriteString("}\n")
+		}
+	}
+
+	for _, node := range nodes {
+		// Use deterministic iteration order
+		for _, child := range node.Children() {
+			attrs := s.edgeAttrs(node, child)
+			fmt.Fprintf(&sb, "%s %s %s", ids[node], plantUMLArrow(attrs), ids[child])
+			if label, exists := attrs["label"]; exists {
+				fmt.Fprintf(&sb, " : %s", plantUMLEscape(label))
+			}
+			sb.WriteString("\n")
+		}
+	}
+	sb.WriteString("@enduml\n")
+	return sb.String()
+}
+
+// exportIDs assigns each node an ID of the form n0, n1, ... in node order.
+func exportIDs[T comparable](nodes []*Node[T]) map[*Node[T]]string {
+	ids := make(map[*Node[T]]string, len(nodes))
+	for i, node := range nodes {
+		ids[node] = fmt.Sprintf("n%d", i)
+	}
+	return ids
+}
+
+// exportLabel returns the "label" attribute, or the node's data if unset.
+func exportLabel[T comparable](node *Node[T], attrs map[string]string) string {
+	if label, exists := attrs["label"]; exists {
+		return label
+	}
+	return fmt.Sprint(node.data)
+}
+
+// mermaidDirection converts a DOT rankdir to a Mermaid flowchart direction.
+func mermaidDirection(rankDir string) string {
+	switch rankDir {
+	case "LR", "RL", "BT":
+		return rankDir
+	default:
+		return "TB"
+	}
+}
+
+// mermaidEscape escapes text for use inside a quoted Mermaid label.
+var mermaidEscape = strings.NewReplacer(
+	`"`, "#quot;",
+	"<", "#lt;",
+	">", "#gt;",
+	"\n", "<br>",
+).Replace
+
+// mermaidShape returns the brackets for a node shape given as a DOT shape.
+func mermaidShape(shape string) (string, string) {
+	switch shape {
+	case "ellipse", "oval":
+		return "([", "])"
+	case "circle", "doublecircle":
+		return "((", "))"
+	case "diamond":
+		return "{", "}"
+	case "hexagon":
+		return "{{", "}}"
+	case "cylinder":
+		return "[(", ")]"
+	default:
+		return "[", "]"
+	}
+}
+
+// mermaidStyle translates DOT styling attributes to a Mermaid style
+// declaration, or returns "" if there are none.
+func mermaidStyle(attrs map[string]string, node bool) string {
+	var parts []string
+	if color, exists := attrs["color"]; exists {
+		parts = append(parts, "stroke:"+color)
+	}
+	if width, exists := attrs["penwidth"]; exists {
+		parts = append(parts, "stroke-width:"+width+"px")
+	}
+	if style := attrs["style"]; strings.Contains(style, "dashed") || strings.Contains(style, "dotted") {
+		parts = append(parts, "stroke-dasharray:5 5")
+	}
+	if node {
+		if fill, exists := attrs["fillcolor"]; exists {
+			parts = append(parts, "fill:"+fill)
+		}
+		if color, exists := attrs["fontcolor"]; exists {
+			parts = append(parts, "color:"+color)
+		}
+	}
+	// Commas separate properties, so they can't appear in values
+	for i, part := range parts {
+		parts[i] = strings.ReplaceAll(part, ",", "")
+	}
+	return strings.Join(parts, ",")
+}
+
+// plantUMLEscape escapes text for use inside a quoted PlantUML string.
+var plantUMLEscape = strings.NewReplacer(
+	`"`, "&#34;",
+	"\n", `\n`,
+).Replace
+
+// plantUMLShape returns the element keyword for a node shape given as a
+// DOT shape.
+func plantUMLShape(shape string) string {
+	switch shape {
+	case "box", "rect", "rectangle":
+		return "rectangle"
+	case "ellipse", "oval":
+		return "usecase"
+	case "circle", "doublecircle":
+		return "circle"
+	case "cylinder":
+		return "database"
+	case "folder", "tab":
+		return "folder"
+	default:
+		return "component"
+	}
+}
+
+// plantUMLNodeStyle translates DOT styling attributes to a PlantUML
+// element color specification with a leading space, or returns "".
+func plantUMLNodeStyle(attrs map[string]string) string {
+	var parts []string
+	if fill, exists := attrs["fillcolor"]; exists {
+		parts = append(parts, plantUMLColor(fill))
+	}
+	if color, exists := attrs["color"]; exists {
+		parts = append(parts, "line:"+strings.TrimPrefix(color, "#"))
+	}
+	if style := attrs["style"]; strings.Contains(style, "dashed") || strings.Contains(style, "dotted") {
+		parts = append(parts, "line.dashed")
+	} else if _, exists := attrs["penwidth"]; exists {
+		parts = append(parts, "line.bold")
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	if !strings.HasPrefix(parts[0], "#") {
+		return " #" + strings.Join(parts, ";")
+	}
+	return " " + strings.Join(parts, ";")
+}
+
+// plantUMLArrow returns an arrow carrying the edge's color, line style and
+// thickness.
+func plantUMLArrow(attrs map[string]string) string {
+	var parts []string
+	if color, exists := attrs["color"]; exists {
+		parts = append(parts, plantUMLColor(color))
+	}
+	if style := attrs["style"]; strings.Contains(style, "dashed") {
+		parts = append(parts, "dashed")
+	} else if strings.Contains(style, "dotted") {
+		parts = append(parts, "dotted")
+	}
+	if width, exists := attrs["penwidth"]; exists {
+		parts = append(parts, "thickness="+width)
+	}
+	if len(parts) == 0 {
+		return "-->"
+	}
+	return "-[" + strings.Join(parts, ",") + "]->"
+}
+
+// plantUMLColor returns a DOT color name or hex value as a PlantUML color.
+func plantUMLColor(color string) string {
+	return "#" + strings.TrimPrefix(color, "#")
+}
@@ -0,0 +1,111 @@
+package dag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVisualizeMermaid(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge(`say "hi"`, "B")
+	dag.AddNode("<D>")
+
+	// Isolated nodes are included and labels are escaped
+	expected := `flowchart TB
+    n0["#lt;D#gt;"]
+    n1["A"]
+    n2["B"]
+    n3["say #quot;hi#quot;"]
+    n1 --> n2
+    n3 --> n2
+`
+	assert.Equal(t, expected, dag.VisualizeMermaid(VisualizeOptions[string]{}))
+}
+
+func TestVisualizeMermaidOptions(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("api", "db")
+	dag.AddEdge("web", "api")
+	dag.AddNode("docs")
+	dag.Node("db").Attrs().Set("shape", "cylinder")
+	dag.EdgeAttrs("api", "db").Set("label", "reads")
+
+	groups := map[string]string{"api": "backend", "db": "backend"}
+	mermaid := dag.VisualizeMermaid(VisualizeOptions[string]{
+		RankDir: "LR",
+		NodeAttrs: func(node *Node[string]) map[string]string {
+			if node.Data() == "docs" {
+				return map[string]string{"fillcolor": "#eee", "style": "dashed"}
+			}
+			return nil
+		},
+		Cluster: func(node *Node[string]) string {
+			return groups[node.Data()]
+		},
+		Highlight: [][]string{{"web", "api"}},
+	})
+	expected := `flowchart LR
+    n2["docs"]
+    n3["web"]
+    subgraph c1["backend"]
+        n0["api"]
+        n1[("db")]
+    end
+    n0 -->|"reads"| n1
+    n3 --> n0
+    style n2 stroke-dasharray:5 5,fill:#eee
+    style n3 stroke:red,stroke-width:2px
+    style n0 stroke:red,stroke-width:2px
+    linkStyle 1 stroke:red,stroke-width:2px
+`
+	assert.Equal(t, expected, mermaid)
+}
+
+func TestVisualizePlantUML(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("api", "db")
+	dag.AddEdge("web", "api")
+	dag.AddNode(`say "hi"`)
+	dag.Node("db").Attrs().Set("shape", "cylinder")
+	dag.EdgeAttrs("api", "db").Set("label", "reads")
+	dag.EdgeAttrs("web", "api").Set("style", "dashed")
+
+	assert.Equal(t, `@startuml
+component "api" as n0
+database "db" as n1
+component "say &#34;hi&#34;" as n2
+component "web" as n3
+n0 --> n1 : reads
+n3 -[dashed]-> n0
+@enduml
+`, dag.VisualizePlantUML(VisualizeOptions[string]{}))
+
+	groups := map[string]string{"api": "backend", "db": "backend"}
+	uml := dag.VisualizePlantUML(VisualizeOptions[string]{
+		RankDir: "LR",
+		NodeAttrs: func(node *Node[string]) map[string]string {
+			if node.Data() == "web" {
+				return map[string]string{"fillcolor": "lightblue"}
+			}
+			return nil
+		},
+		Cluster: func(node *Node[string]) string {
+			return groups[node.Data()]
+		},
+		Highlight: [][]string{{"api", "db"}},
+	})
+	assert.Equal(t, `@startuml
+left to right direction
+component "say &#34;hi&#34;" as n2
+component "web" as n3 #lightblue
+package "backend" {
+  component "api" as n0 #line:red;line.bold
+  database "db" as n1 #line:red;line.bold
+}
+n0 -[#red,thickness=2]-> n1 : reads
+n3 -[dashed]-> n0
+@enduml
+`, uml)
+}
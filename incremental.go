@@ -0,0 +1,144 @@
+package dag
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+)
+
+// Edge is a directed edge between the nodes with data From and To.
+type Edge[T comparable] struct {
+	From, To T
+}
+
+// AddEdges adds a batch of directed edges. The whole batch is first checked
+// with a single pass over the graph; if it is acyclic, the topological order
+// is recomputed once rather than updated for every edge. Otherwise the edges
+// are added one at a time, in order, and each edge that would create a cycle
+// with the graph so far is skipped.
+//
+// The returned error joins a *CycleError for every skipped edge, and can be
+// inspected with errors.Is, errors.As, or by unwrapping it into a []error.
+func (d *DAG[T]) AddEdges(edges []Edge[T]) error {
+	var added []Edge[T]
+	for _, edge := range edges {
+		fromNode := d.AddNode(edge.From)
+		toNode := d.AddNode(edge.To)
+		if _, exists := fromNode.children[toNode]; exists {
+			continue
+		}
+		fromNode.addChild(toNode)
+		toNode.addParent(fromNode)
+		added = append(added, edge)
+	}
+	if d.renumber() {
+		for _, edge := range added {
+			d.emit(Event[T]{Type: EdgeAdded, From: edge.From, To: edge.To})
+		}
+		return nil
+	}
+
+	// Roll back the batch, then find the offending edges one by one.
+	// The topological order is untouched, so it is still valid.
+	for _, edge := range added {
+		fromNode, toNode := d.nodes[edge.From], d.nodes[edge.To]
+		delete(fromNode.children, toNode)
+		delete(toNode.parents, fromNode)
+	}
+	var errs []error
+	for _, edge := range edges {
+		if err := d.AddEdge(edge.From, edge.To); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// reorderForEdge updates the topological order of the DAG to account for a
+// new edge from -> to, using the Pearce-Kelly dynamic topological sort. It
+// returns false, leaving the order unchanged, if the edge would create a
+// cycle. Only nodes positioned between the two endpoints are visited.
+func (d *DAG[T]) reorderForEdge(from, to *Node[T]) bool {
+	if from.ord < to.ord {
+		return true
+	}
+	lower, upper := to.ord, from.ord
+
+	// Nodes reachable from 'to' that are currently ordered before 'from'.
+	// Reaching 'from' itself means the edge closes a cycle.
+	visited := map[*Node[T]]struct{}{to: {}}
+	forward := []*Node[T]{to}
+	for i := 0; i < len(forward); i++ {
+		if forward[i] == from {
+			return false
+		}
+		for child := range forward[i].children {
+			if _, seen := visited[child]; !seen && child.ord <= upper {
+				visited[child] = struct{}{}
+				forward = append(forward, child)
+			}
+		}
+	}
+
+	// Nodes that reach 'from' and are currently ordered after 'to'
+	visited = map[*Node[T]]struct{}{from: {}}
+	backward := []*Node[T]{from}
+	for i := 0; i < len(backward); i++ {
+		for parent := range backward[i].parents {
+			if _, seen := visited[parent]; !seen && parent.ord > lower {
+				visited[parent] = struct{}{}
+				backward = append(backward, parent)
+			}
+		}
+	}
+
+	// Reuse the positions of both sets, placing everything that reaches
+	// 'from' before everything reachable from 'to'
+	byOrd := func(a, b *Node[T]) int {
+		return cmp.Compare(a.ord, b.ord)
+	}
+	slices.SortFunc(forward, byOrd)
+	slices.SortFunc(backward, byOrd)
+	ords := make([]uint64, 0, len(forward)+len(backward))
+	for _, node := range backward {
+		ords = append(ords, node.ord)
+	}
+	for _, node := range forward {
+		ords = append(ords, node.ord)
+	}
+	slices.Sort(ords)
+	for i, node := range append(backward, forward...) {
+		node.ord = ords[i]
+	}
+	return true
+}
+
+// renumber recomputes the topological order of the DAG from scratch,
+// returning false without changing it if the graph contains a cycle.
+func (d *DAG[T]) renumber() bool {
+	// Callers change edges directly before renumbering
+	d.invalidateReach()
+	inDegree := d.inDegrees()
+	var queue []*Node[T]
+	for node, degree := range inDegree {
+		if degree == 0 {
+			queue = append(queue, node)
+		}
+	}
+	for i := 0; i < len(queue); i++ {
+		for child := range queue[i].children {
+			inDegree[child]--
+			if inDegree[child] == 0 {
+				queue = append(queue, child)
+			}
+		}
+	}
+	if len(queue) < len(d.nodes) {
+		return false
+	}
+
+	for i, node := range queue {
+		node.ord = uint64(i)
+	}
+	return true
+}
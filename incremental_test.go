@@ -0,0 +1,145 @@
+package dag
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertTopologicalOrder asserts that the incrementally maintained order
+// places every node before its children.
+func assertTopologicalOrder[T comparable](t *testing.T, dag *DAG[T]) {
+	t.Helper()
+	for _, edge := range dag.Edges() {
+		assert.Less(t, edge[0].ord, edge[1].ord, "Expected %v to be ordered before %v", edge[0].Data(), edge[1].Data())
+	}
+}
+
+func TestAddEdgeIncrementalOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	dag := NewDAG[int]()
+
+	for i := 0; i < 2000; i++ {
+		from, to := r.Intn(100), r.Intn(100)
+		cyclic := from == to || dag.HasPath(to, from)
+
+		err := dag.AddEdge(from, to)
+		if cyclic {
+			assert.ErrorIs(t, err, ErrCycleDetected, "Expected %d -> %d to be rejected", from, to)
+		} else {
+			assert.NoError(t, err, "Expected %d -> %d to be added", from, to)
+		}
+	}
+
+	assertTopologicalOrder(t, dag)
+}
+
+func TestAddEdges(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+
+	err := dag.AddEdges([]Edge[string]{
+		{From: "B", To: "C"},
+		{From: "C", To: "D"},
+		{From: "A", To: "B"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, dag.HasPath("A", "D"))
+	assert.Len(t, dag.Edges(), 3)
+	assertTopologicalOrder(t, dag)
+
+	// A batch that closes two separate cycles reports both edges and
+	// still adds the rest
+	err = dag.AddEdges([]Edge[string]{
+		{From: "D", To: "E"},
+		{From: "D", To: "A"},
+		{From: "E", To: "F"},
+		{From: "F", To: "E"},
+		{From: "X", To: "X"},
+	})
+	assert.ErrorIs(t, err, ErrCycleDetected)
+
+	var errs []error
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	assert.Len(t, errs, 3, "Expected an error for each offending edge")
+
+	var cycles [][]string
+	for _, err := range errs {
+		var cycleErr *CycleError[string]
+		assert.True(t, errors.As(err, &cycleErr), "Expected a *CycleError")
+		cycles = append(cycles, cycleErr.Cycle())
+	}
+	assert.Equal(t, [][]string{
+		{"D", "A", "B", "C", "D"},
+		{"F", "E", "F"},
+		{"X", "X"},
+	}, cycles)
+
+	assert.True(t, dag.HasEdge("D", "E"))
+	assert.True(t, dag.HasEdge("E", "F"))
+	assert.False(t, dag.HasEdge("D", "A"))
+	assert.False(t, dag.HasEdge("F", "E"))
+	assertTopologicalOrder(t, dag)
+}
+
+func TestAddEdgesReordersNodes(t *testing.T) {
+	// Adding edges against insertion order forces the order to be rebuilt
+	dag := NewDAG[int]()
+	var edges []Edge[int]
+	for i := 10; i > 0; i-- {
+		dag.AddNode(i)
+		edges = append(edges, Edge[int]{From: i, To: i - 1})
+	}
+	assert.NoError(t, dag.AddEdges(edges))
+	assertTopologicalOrder(t, dag)
+
+	// Further incremental additions keep the order valid
+	assert.NoError(t, dag.AddEdge(20, 10))
+	assert.ErrorIs(t, dag.AddEdge(0, 20), ErrCycleDetected)
+	assertTopologicalOrder(t, dag)
+}
+
+func TestTransitiveOrder(t *testing.T) {
+	dag := NewDAG[int]()
+	dag.AddEdge(3, 2)
+	dag.AddEdge(2, 1)
+	dag.AddEdge(3, 1)
+
+	reduced := dag.TransitiveReduction()
+	assertTopologicalOrder(t, reduced)
+	assert.ErrorIs(t, reduced.AddEdge(1, 3), ErrCycleDetected)
+
+	closure := dag.TransitiveClosure()
+	assertTopologicalOrder(t, closure)
+	assert.ErrorIs(t, closure.AddEdge(1, 3), ErrCycleDetected)
+}
+
+func BenchmarkDAGAddEdgeReverse(b *testing.B) {
+	edges := benchmarkEdges(benchmarkNodes)
+	for b.Loop() {
+		dag := NewDAG(WithOrdered[int]())
+		// Add nodes in reverse so that each edge goes against the
+		// current order and forces a reorder
+		for i := benchmarkNodes - 1; i >= 0; i-- {
+			dag.AddNode(i)
+		}
+		for _, edge := range edges {
+			dag.AddEdge(edge[0], edge[1])
+		}
+	}
+}
+
+func BenchmarkDAGAddEdges(b *testing.B) {
+	edges := make([]Edge[int], 0, benchmarkNodes*4)
+	for _, edge := range benchmarkEdges(benchmarkNodes) {
+		edges = append(edges, Edge[int]{From: edge[0], To: edge[1]})
+	}
+	for b.Loop() {
+		dag := NewDAG(WithOrdered[int]())
+		dag.AddEdges(edges)
+	}
+}
@@ -0,0 +1,116 @@
+package dag
+
+import "iter"
+
+// DFS returns an iterator over the nodes reachable from the node with the
+// given data, in depth-first order. It yields the same sequence as Walk but
+// stops as soon as the loop body breaks.
+func (d *DAG[T]) DFS(start T) iter.Seq[*Node[T]] {
+	return d.depthFirst(start, (*Node[T]).Children)
+}
+
+// ReverseDFS returns an iterator over the nodes that can reach the node with
+// the given data, in depth-first order following parents. It yields the same
+// sequence as ReverseWalk.
+func (d *DAG[T]) ReverseDFS(start T) iter.Seq[*Node[T]] {
+	return d.depthFirst(start, (*Node[T]).Parents)
+}
+
+// BFS returns an iterator over the nodes reachable from the node with the
+// given data, in breadth-first order. It yields the same sequence as
+// BreadthFirstWalk.
+func (d *DAG[T]) BFS(start T) iter.Seq[*Node[T]] {
+	return d.breadthFirst(start, (*Node[T]).Children)
+}
+
+// ReverseBFS returns an iterator over the nodes that can reach the node with
+// the given data, in breadth-first order following parents. It yields the
+// same sequence as ReverseBreadthFirstWalk.
+func (d *DAG[T]) ReverseBFS(start T) iter.Seq[*Node[T]] {
+	return d.breadthFirst(start, (*Node[T]).Parents)
+}
+
+// Topological returns an iterator over all nodes of the DAG in topological
+// order. Nodes are sorted lazily, so breaking early skips the remaining work.
+func (d *DAG[T]) Topological() iter.Seq[*Node[T]] {
+	return func(yield func(*Node[T]) bool) {
+		inDegree := d.inDegrees()
+		queue := d.Roots()
+
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			if !yield(current) {
+				return
+			}
+
+			// Use deterministic iteration order
+			for _, child := range current.Children() {
+				inDegree[child]--
+				if inDegree[child] == 0 {
+					queue = append(queue, child)
+				}
+			}
+		}
+	}
+}
+
+// depthFirst returns a pre-order depth-first iterator from start, using next
+// to find the neighbours of each node.
+func (d *DAG[T]) depthFirst(start T, next func(*Node[T]) []*Node[T]) iter.Seq[*Node[T]] {
+	return func(yield func(*Node[T]) bool) {
+		startNode := d.nodes[start]
+		if startNode == nil {
+			return
+		}
+
+		visited := make(map[*Node[T]]struct{})
+		var walk func(node *Node[T]) bool
+		walk = func(node *Node[T]) bool {
+			if _, seen := visited[node]; seen {
+				return true
+			}
+			visited[node] = struct{}{}
+			if !yield(node) {
+				return false
+			}
+			for _, neighbour := range next(node) {
+				if !walk(neighbour) {
+					return false
+				}
+			}
+			return true
+		}
+		walk(startNode)
+	}
+}
+
+// breadthFirst returns a breadth-first iterator from start, using next
+// to find the neighbours of each node.
+func (d *DAG[T]) breadthFirst(start T, next func(*Node[T]) []*Node[T]) iter.Seq[*Node[T]] {
+	return func(yield func(*Node[T]) bool) {
+		startNode := d.nodes[start]
+		if startNode == nil {
+			return
+		}
+
+		visited := make(map[*Node[T]]struct{})
+		queue := []*Node[T]{startNode}
+		visited[startNode] = struct{}{}
+
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			if !yield(current) {
+				return
+			}
+
+			for _, neighbour := range next(current) {
+				if _, seen := visited[neighbour]; !seen {
+					visited[neighbour] = struct{}{}
+					queue = append(queue, neighbour)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package dag
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newIterTestDAG() *DAG[string] {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("A", "C")
+	dag.AddEdge("B", "D")
+	dag.AddEdge("C", "D")
+	dag.AddEdge("D", "E")
+	return dag
+}
+
+func collectData[T comparable](seq iter.Seq[*Node[T]]) []T {
+	var data []T
+	for node := range seq {
+		data = append(data, node.Data())
+	}
+	return data
+}
+
+func TestDFS(t *testing.T) {
+	dag := newIterTestDAG()
+
+	var walked []string
+	dag.Walk("A", func(v string) { walked = append(walked, v) })
+
+	assert.Equal(t, []string{"A", "B", "D", "E", "C"}, collectData(dag.DFS("A")))
+	assert.Equal(t, walked, collectData(dag.DFS("A")), "Expected DFS to match Walk")
+	assert.Empty(t, collectData(dag.DFS("Z")), "Expected no nodes from a non-existent start")
+
+	// Breaking early stops the traversal
+	var visited []string
+	for node := range dag.DFS("A") {
+		visited = append(visited, node.Data())
+		if node.Data() == "D" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"A", "B", "D"}, visited)
+}
+
+func TestReverseDFS(t *testing.T) {
+	dag := newIterTestDAG()
+
+	var walked []string
+	dag.ReverseWalk("E", func(v string) { walked = append(walked, v) })
+
+	assert.Equal(t, []string{"E", "D", "B", "A", "C"}, collectData(dag.ReverseDFS("E")))
+	assert.Equal(t, walked, collectData(dag.ReverseDFS("E")), "Expected ReverseDFS to match ReverseWalk")
+}
+
+func TestBFS(t *testing.T) {
+	dag := newIterTestDAG()
+
+	assert.Equal(t, []string{"A", "B", "C", "D", "E"}, collectData(dag.BFS("A")))
+	assert.Empty(t, collectData(dag.BFS("Z")), "Expected no nodes from a non-existent start")
+
+	// Composes with slices helpers
+	found := slices.ContainsFunc(slices.Collect(dag.BFS("B")), func(node *Node[string]) bool {
+		return node.Data() == "C"
+	})
+	assert.False(t, found, "Expected C to be unreachable from B")
+
+	var visited []string
+	for node := range dag.BFS("A") {
+		if node.Data() == "C" {
+			break
+		}
+		visited = append(visited, node.Data())
+	}
+	assert.Equal(t, []string{"A", "B"}, visited)
+}
+
+func TestReverseBFS(t *testing.T) {
+	dag := newIterTestDAG()
+
+	assert.Equal(t, []string{"E", "D", "B", "C", "A"}, collectData(dag.ReverseBFS("E")))
+}
+
+func TestTopological(t *testing.T) {
+	dag := newIterTestDAG()
+
+	assert.Equal(t, []string{"A", "B", "C", "D", "E"}, collectData(dag.Topological()))
+
+	sorted, err := dag.Traverse()
+	assert.NoError(t, err)
+	assert.Equal(t, sorted, slices.Collect(dag.Topological()), "Expected Topological to match Traverse")
+
+	var visited []string
+	for node := range dag.Topological() {
+		visited = append(visited, node.Data())
+		if len(visited) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"A", "B"}, visited)
+
+	assert.Empty(t, collectData(NewDAG[string]().Topological()))
+}
@@ -0,0 +1,163 @@
+package dag
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Codec converts node data to and from its JSON representation.
+type Codec[T comparable] interface {
+	Encode(v T) (json.RawMessage, error)
+	Decode(data json.RawMessage) (T, error)
+}
+
+// JSONCodec is a Codec that uses encoding/json for node data.
+type JSONCodec[T comparable] struct{}
+
+// Encode marshals v with encoding/json.
+func (JSONCodec[T]) Encode(v T) (json.RawMessage, error) {
+	return json.Marshal(v)
+}
+
+// Decode unmarshals data with encoding/json.
+func (JSONCodec[T]) Decode(data json.RawMessage) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+// jsonDocument is the serialized form of a DAG. Edges and node attributes
+// refer to nodes by their index in Nodes.
+type jsonDocument struct {
+	Nodes     []json.RawMessage         `json:"nodes"`
+	Edges     []jsonEdge                `json:"edges"`
+	NodeAttrs map[int]map[string]string `json:"nodeAttrs,omitempty"`
+}
+
+type jsonEdge struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+	// Weight is omitted for edges with DefaultEdgeWeight.
+	Weight *float64          `json:"weight,omitempty"`
+	Attrs  map[string]string `json:"attrs,omitempty"`
+}
+
+// Encode serializes the DAG to JSON, using codec to encode node data.
+// Nodes and edges are written in deterministic order, so encoding the
+// same graph always produces the same document.
+func Encode[T comparable](d *DAG[T], codec Codec[T]) ([]byte, error) {
+	nodes := d.Nodes()
+
+	doc := jsonDocument{
+		Nodes: make([]json.RawMessage, len(nodes)),
+		Edges: []jsonEdge{},
+	}
+	index := make(map[*Node[T]]int, len(nodes))
+	for i, node := range nodes {
+		data, err := codec.Encode(node.data)
+		if err != nil {
+			return nil, fmt.Errorf("encoding node %v: %w", node.data, err)
+		}
+		doc.Nodes[i] = data
+		index[node] = i
+		if node.attrs.Len() > 0 {
+			if doc.NodeAttrs == nil {
+				doc.NodeAttrs = make(map[int]map[string]string)
+			}
+			doc.NodeAttrs[i] = node.attrs.values
+		}
+	}
+	for i, node := range nodes {
+		// Use deterministic iteration order
+		for _, child := range node.Children() {
+			edge := jsonEdge{From: i, To: index[child]}
+			if weight := node.children[child]; weight != DefaultEdgeWeight {
+				edge.Weight = &weight
+			}
+			if attrs := node.edgeAttrs[child]; attrs != nil && attrs.Len() > 0 {
+				edge.Attrs = attrs.values
+			}
+			doc.Edges = append(doc.Edges, edge)
+		}
+	}
+
+	return json.Marshal(doc)
+}
+
+// Decode builds a new DAG configured with opts from JSON produced by Encode,
+// using codec to decode node data. It returns an error identifying the
+// offending edge if the document describes a cycle.
+func Decode[T comparable](data []byte, codec Codec[T], opts ...Option[T]) (*DAG[T], error) {
+	return decodeInto(NewDAG(opts...), data, codec)
+}
+
+// decodeInto decodes data into the empty DAG d and returns it.
+func decodeInto[T comparable](d *DAG[T], data []byte, codec Codec[T]) (*DAG[T], error) {
+	var doc jsonDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	values := make([]T, len(doc.Nodes))
+	for i, raw := range doc.Nodes {
+		v, err := codec.Decode(raw)
+		if err != nil {
+			return nil, fmt.Errorf("decoding node %d: %w", i, err)
+		}
+		d.AddNode(v)
+		values[i] = v
+	}
+	for i, attrs := range doc.NodeAttrs {
+		if i < 0 || i >= len(values) {
+			return nil, fmt.Errorf("attributes of node %d: node index out of range", i)
+		}
+		d.nodes[values[i]].attrs.values = attrs
+	}
+	for i, edge := range doc.Edges {
+		if edge.From < 0 || edge.From >= len(values) || edge.To < 0 || edge.To >= len(values) {
+			return nil, fmt.Errorf("edge %d (%d -> %d): node index out of range", i, edge.From, edge.To)
+		}
+		weight := DefaultEdgeWeight
+		if edge.Weight != nil {
+			weight = *edge.Weight
+		}
+		if err := d.AddWeightedEdge(values[edge.From], values[edge.To], weight); err != nil {
+			return nil, fmt.Errorf("edge %d (%v -> %v): %w", i, values[edge.From], values[edge.To], err)
+		}
+		if len(edge.Attrs) > 0 {
+			d.EdgeAttrs(values[edge.From], values[edge.To]).values = edge.Attrs
+		}
+	}
+
+	return d, nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding node data with encoding/json.
+func (d *DAG[T]) MarshalJSON() ([]byte, error) {
+	return Encode(d, JSONCodec[T]{})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding node data with
+// encoding/json. It replaces the contents of the DAG, keeping its ordering
+// and observers, which see a Cleared event followed by the decoded nodes
+// and edges.
+func (d *DAG[T]) UnmarshalJSON(data []byte) error {
+	decoded, err := decodeInto(d.emptyCopy(), data, JSONCodec[T]{})
+	if err != nil {
+		return err
+	}
+	d.nodes = decoded.nodes
+	d.nextSeq = decoded.nextSeq
+	d.invalidateReach()
+
+	if d.observed() {
+		d.emit(Event[T]{Type: Cleared})
+		for _, node := range d.Nodes() {
+			d.emit(Event[T]{Type: NodeAdded, Node: node.data})
+		}
+		for _, edge := range d.Edges() {
+			d.emit(Event[T]{Type: EdgeAdded, From: edge[0].data, To: edge[1].data})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package dag
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("B", "C")
+	dag.AddEdge("A", "B")
+	dag.AddEdge("A", "C")
+	dag.AddNode("D")
+
+	data, err := json.Marshal(dag)
+	assert.NoError(t, err)
+
+	expected := `{"nodes":["A","B","C","D"],"edges":[{"from":0,"to":1},{"from":0,"to":2},{"from":1,"to":2}]}`
+	assert.JSONEq(t, expected, string(data))
+
+	// Encoding is stable across calls
+	again, err := json.Marshal(dag)
+	assert.NoError(t, err)
+	assert.Equal(t, string(data), string(again), "Expected identical output for the same graph")
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	data := []byte(`{"nodes":[1,2,3,4],"edges":[{"from":0,"to":1},{"from":1,"to":2}]}`)
+
+	var dag DAG[int]
+	assert.NoError(t, json.Unmarshal(data, &dag))
+
+	assert.Len(t, dag.Nodes(), 4)
+	assert.True(t, dag.HasEdge(1, 2))
+	assert.True(t, dag.HasEdge(2, 3))
+	assert.NotNil(t, dag.Node(4), "Expected isolated node to be restored")
+
+	// Round trip
+	out, err := json.Marshal(&dag)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(data), string(out))
+}
+
+func TestUnmarshalJSONCycle(t *testing.T) {
+	data := []byte(`{"nodes":["A","B"],"edges":[{"from":0,"to":1},{"from":1,"to":0}]}`)
+
+	var dag DAG[string]
+	err := json.Unmarshal(data, &dag)
+	assert.ErrorIs(t, err, ErrCycleDetected)
+	assert.Contains(t, err.Error(), "edge 1 (B -> A)")
+
+	var cycleErr *CycleError[string]
+	assert.True(t, errors.As(err, &cycleErr), "Expected a *CycleError")
+
+	data = []byte(`{"nodes":["A"],"edges":[{"from":0,"to":3}]}`)
+	err = json.Unmarshal(data, &dag)
+	assert.ErrorContains(t, err, "out of range")
+}
+
+// widgetCodec encodes widgets as "id:name" strings.
+type widgetCodec struct{}
+
+func (widgetCodec) Encode(w *widget) (json.RawMessage, error) {
+	return json.Marshal(fmt.Sprintf("%d:%s", w.id, w.name))
+}
+
+func (widgetCodec) Decode(data json.RawMessage) (*widget, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	idStr, name, _ := strings.Cut(s, ":")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return nil, err
+	}
+	return newWidget(id, name), nil
+}
+
+func TestEncodeDecodeCodec(t *testing.T) {
+	dag := NewDAG[*widget]()
+	dag.AddEdge(newWidget(1, "Widget1"), newWidget(2, "Widget2"))
+
+	data, err := Encode(dag, widgetCodec{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"nodes":["1:Widget1","2:Widget2"],"edges":[{"from":0,"to":1}]}`, string(data))
+
+	decoded, err := Decode(data, widgetCodec{})
+	assert.NoError(t, err)
+	assert.Len(t, decoded.Nodes(), 2)
+
+	edges := decoded.Edges()
+	assert.Len(t, edges, 1)
+	assert.Equal(t, 1, edges[0][0].Data().id)
+	assert.Equal(t, "Widget2", edges[0][1].Data().name)
+}
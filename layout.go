@@ -0,0 +1,314 @@
+package dag
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"unicode/utf8"
+)
+
+// Sizes used by the layered layout, in pixels.
+const (
+	layoutMargin      = 20.0
+	layoutNodeHeight  = 36.0
+	layoutNodePadding = 12.0
+	layoutCharWidth   = 8.0
+	layoutNodeGap     = 24.0
+	layoutRankGap     = 56.0
+	layoutDummyWidth  = 8.0
+)
+
+// layoutNode is a node in a layered layout, or a bend point of an edge that
+// spans more than one rank, in which case node is nil.
+type layoutNode[T comparable] struct {
+	node          *Node[T]
+	label         string
+	width, height float64
+	rank, pos     int
+	// x and y are the coordinates of the center.
+	x, y float64
+	// up and down are the neighbors in the previous and next ranks.
+	up, down []*layoutNode[T]
+}
+
+// layoutEdge is an edge routed through zero or more bend points.
+type layoutEdge[T comparable] struct {
+	from, to *Node[T]
+	// points runs from the source node to the target node, including both.
+	points []*layoutNode[T]
+}
+
+// layout is a Sugiyama-style layered drawing of a DAG: nodes are assigned to
+// ranks by LevelOrder, ordered within each rank to reduce edge crossings,
+// and then given coordinates.
+type layout[T comparable] struct {
+	ranks         [][]*layoutNode[T]
+	nodes         map[*Node[T]]*layoutNode[T]
+	edges         []layoutEdge[T]
+	horizontal    bool
+	width, height float64
+}
+
+// newLayout lays out the DAG with the given node labels. rankDir is one of
+// "TB" (the default), "BT", "LR" or "RL".
+func newLayout[T comparable](d *DAG[T], label func(node *Node[T]) string, rankDir string) *layout[T] {
+	l := &layout[T]{
+		nodes:      make(map[*Node[T]]*layoutNode[T]),
+		horizontal: rankDir == "LR" || rankDir == "RL",
+	}
+	for rank, level := range d.LevelOrder() {
+		l.ranks = append(l.ranks, nil)
+		for _, node := range level {
+			text := label(node)
+			ln := &layoutNode[T]{
+				node:   node,
+				label:  text,
+				width:  float64(utf8.RuneCountInString(text))*layoutCharWidth + 2*layoutNodePadding,
+				height: layoutNodeHeight,
+				rank:   rank,
+			}
+			l.nodes[node] = ln
+			l.ranks[rank] = append(l.ranks[rank], ln)
+		}
+	}
+
+	// Split edges that span several ranks so that every segment joins
+	// adjacent ranks
+	for _, node := range d.Nodes() {
+		// Use deterministic iteration order
+		for _, child := range node.Children() {
+			from, to := l.nodes[node], l.nodes[child]
+			points := []*layoutNode[T]{from}
+			for rank := from.rank + 1; rank < to.rank; rank++ {
+				dummy := &layoutNode[T]{width: layoutDummyWidth, height: layoutDummyWidth, rank: rank}
+				l.ranks[rank] = append(l.ranks[rank], dummy)
+				points = append(points, dummy)
+			}
+			points = append(points, to)
+			for i := 1; i < len(points); i++ {
+				points[i-1].down = append(points[i-1].down, points[i])
+				points[i].up = append(points[i].up, points[i-1])
+			}
+			l.edges = append(l.edges, layoutEdge[T]{from: node, to: child, points: points})
+		}
+	}
+
+	l.orderRanks()
+	l.assignCoordinates(rankDir == "BT" || rankDir == "RL")
+	return l
+}
+
+// orderRanks reorders the nodes within each rank to reduce edge crossings,
+// using alternating downward and upward barycenter sweeps and keeping the
+// best order found.
+func (l *layout[T]) orderRanks() {
+	l.updatePositions()
+	best := l.crossings()
+	bestOrder := l.snapshot()
+
+	const sweeps = 24
+	for i := 0; i < sweeps && best > 0; i++ {
+		if i%2 == 0 {
+			for rank := 1; rank < len(l.ranks); rank++ {
+				l.sortByBarycenter(rank, func(n *layoutNode[T]) []*layoutNode[T] { return n.up })
+			}
+		} else {
+			for rank := len(l.ranks) - 2; rank >= 0; rank-- {
+				l.sortByBarycenter(rank, func(n *layoutNode[T]) []*layoutNode[T] { return n.down })
+			}
+		}
+		if crossings := l.crossings(); crossings < best {
+			best = crossings
+			bestOrder = l.snapshot()
+		}
+	}
+
+	l.ranks = bestOrder
+	l.updatePositions()
+}
+
+// sortByBarycenter sorts a rank by the mean position of each node's
+// neighbors in an adjacent rank. Nodes without neighbors keep their place.
+func (l *layout[T]) sortByBarycenter(rank int, neighbors func(n *layoutNode[T]) []*layoutNode[T]) {
+	weights := make(map[*layoutNode[T]]float64, len(l.ranks[rank]))
+	for _, n := range l.ranks[rank] {
+		adjacent := neighbors(n)
+		if len(adjacent) == 0 {
+			weights[n] = float64(n.pos)
+			continue
+		}
+		sum := 0
+		for _, neighbor := range adjacent {
+			sum += neighbor.pos
+		}
+		weights[n] = float64(sum) / float64(len(adjacent))
+	}
+	slices.SortStableFunc(l.ranks[rank], func(a, b *layoutNode[T]) int {
+		return cmp.Compare(weights[a], weights[b])
+	})
+	for pos, n := range l.ranks[rank] {
+		n.pos = pos
+	}
+}
+
+// crossings counts the pairs of segments that cross between adjacent ranks.
+func (l *layout[T]) crossings() int {
+	count := 0
+	for rank := 0; rank+1 < len(l.ranks); rank++ {
+		var segments [][2]int
+		for _, n := range l.ranks[rank] {
+			for _, child := range n.down {
+				segments = append(segments, [2]int{n.pos, child.pos})
+			}
+		}
+		for i, a := range segments {
+			for _, b := range segments[i+1:] {
+				if (a[0] < b[0] && a[1] > b[1]) || (a[0] > b[0] && a[1] < b[1]) {
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
+
+// snapshot returns a copy of the order of every rank.
+func (l *layout[T]) snapshot() [][]*layoutNode[T] {
+	ranks := make([][]*layoutNode[T], len(l.ranks))
+	for i, rank := range l.ranks {
+		ranks[i] = slices.Clone(rank)
+	}
+	return ranks
+}
+
+// updatePositions sets the pos of every node from its index in its rank.
+func (l *layout[T]) updatePositions() {
+	for _, rank := range l.ranks {
+		for pos, n := range rank {
+			n.pos = pos
+		}
+	}
+}
+
+// breadth returns the extent of a node along its rank.
+func (l *layout[T]) breadth(n *layoutNode[T]) float64 {
+	if l.horizontal {
+		return n.height
+	}
+	return n.width
+}
+
+// depth returns the extent of a node across its rank.
+func (l *layout[T]) depth(n *layoutNode[T]) float64 {
+	if l.horizontal {
+		return n.width
+	}
+	return n.height
+}
+
+// assignCoordinates positions the nodes. Along each rank, nodes are placed
+// in order and pulled towards their neighbors in adjacent ranks so that
+// edges run as straight as possible; ranks are stacked with a fixed gap.
+func (l *layout[T]) assignCoordinates(reverse bool) {
+	// Positions along the ranks, as if they were drawn top to bottom
+	along := make(map[*layoutNode[T]]float64)
+	for _, rank := range l.ranks {
+		cursor := 0.0
+		for _, n := range rank {
+			along[n] = cursor + l.breadth(n)/2
+			cursor += l.breadth(n) + layoutNodeGap
+		}
+	}
+
+	const passes = 8
+	for i := 0; i < passes; i++ {
+		if i%2 == 0 {
+			for rank := 1; rank < len(l.ranks); rank++ {
+				l.align(l.ranks[rank], along, func(n *layoutNode[T]) []*layoutNode[T] { return n.up })
+			}
+		} else {
+			for rank := len(l.ranks) - 2; rank >= 0; rank-- {
+				l.align(l.ranks[rank], along, func(n *layoutNode[T]) []*layoutNode[T] { return n.down })
+			}
+		}
+	}
+
+	minAlong, maxAlong := math.Inf(1), math.Inf(-1)
+	for _, rank := range l.ranks {
+		for _, n := range rank {
+			minAlong = min(minAlong, along[n]-l.breadth(n)/2)
+			maxAlong = max(maxAlong, along[n]+l.breadth(n)/2)
+		}
+	}
+	if len(l.ranks) == 0 {
+		minAlong, maxAlong = 0, 0
+	}
+
+	// Positions across the ranks, from the first rank to the last
+	across := make([]float64, len(l.ranks))
+	cursor := 0.0
+	for i, rank := range l.ranks {
+		extent := 0.0
+		for _, n := range rank {
+			extent = max(extent, l.depth(n))
+		}
+		across[i] = cursor + extent/2
+		cursor += extent + layoutRankGap
+	}
+	total := max(cursor-layoutRankGap, 0)
+
+	for i, rank := range l.ranks {
+		for _, n := range rank {
+			a := along[n] - minAlong + layoutMargin
+			b := across[i] + layoutMargin
+			if reverse {
+				b = total - across[i] + layoutMargin
+			}
+			if l.horizontal {
+				n.x, n.y = b, a
+			} else {
+				n.x, n.y = a, b
+			}
+		}
+	}
+
+	breadth, depth := maxAlong-minAlong+2*layoutMargin, total+2*layoutMargin
+	if l.horizontal {
+		l.width, l.height = depth, breadth
+	} else {
+		l.width, l.height = breadth, depth
+	}
+}
+
+// align moves the nodes of a rank towards the mean position of their
+// neighbors while keeping them in order and apart. Packing from the left and
+// from the right each gives a valid placement; their average is used so that
+// neither side is favored.
+func (l *layout[T]) align(rank []*layoutNode[T], along map[*layoutNode[T]]float64, neighbors func(n *layoutNode[T]) []*layoutNode[T]) {
+	desired := make([]float64, len(rank))
+	for i, n := range rank {
+		desired[i] = along[n]
+		if adjacent := neighbors(n); len(adjacent) > 0 {
+			sum := 0.0
+			for _, neighbor := range adjacent {
+				sum += along[neighbor]
+			}
+			desired[i] = sum / float64(len(adjacent))
+		}
+	}
+
+	gap := func(i int) float64 {
+		return (l.breadth(rank[i])+l.breadth(rank[i+1]))/2 + layoutNodeGap
+	}
+	left := slices.Clone(desired)
+	for i := 1; i < len(rank); i++ {
+		left[i] = max(left[i], left[i-1]+gap(i-1))
+	}
+	right := slices.Clone(desired)
+	for i := len(rank) - 2; i >= 0; i-- {
+		right[i] = min(right[i], right[i+1]-gap(i))
+	}
+	for i, n := range rank {
+		along[n] = (left[i] + right[i]) / 2
+	}
+}
@@ -0,0 +1,98 @@
+package dag
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func labelData[T comparable](node *Node[T]) string {
+	return fmt.Sprint(node.Data())
+}
+
+// assertLayout asserts that edges point from earlier to later ranks and
+// that nodes within a rank are in order without overlapping.
+func assertLayout[T comparable](t *testing.T, l *layout[T]) {
+	t.Helper()
+	for _, edge := range l.edges {
+		for i := 1; i < len(edge.points); i++ {
+			assert.Equal(t, edge.points[i-1].rank+1, edge.points[i].rank, "Expected edge segments to join adjacent ranks")
+		}
+	}
+	for _, rank := range l.ranks {
+		for i := 1; i < len(rank); i++ {
+			prev, n := rank[i-1], rank[i]
+			gap := n.x - n.width/2 - (prev.x + prev.width/2)
+			if l.horizontal {
+				gap = n.y - n.height/2 - (prev.y + prev.height/2)
+			}
+			assert.GreaterOrEqual(t, gap, layoutNodeGap-0.001, "Expected nodes in a rank not to overlap")
+		}
+	}
+}
+
+func TestLayout(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("A", "C")
+	dag.AddEdge("B", "D")
+	dag.AddEdge("A", "D")
+	dag.AddNode("E")
+
+	l := newLayout(dag, labelData, "")
+	assertLayout(t, l)
+	assert.Len(t, l.ranks, 3)
+	assert.Len(t, l.ranks[1], 3, "Expected a bend point for the long edge A -> D")
+	assert.Less(t, l.nodes[dag.Node("A")].y, l.nodes[dag.Node("B")].y)
+	assert.Less(t, l.nodes[dag.Node("B")].y, l.nodes[dag.Node("D")].y)
+
+	// Every node fits inside the drawing
+	for _, n := range l.nodes {
+		assert.GreaterOrEqual(t, n.x-n.width/2, 0.0)
+		assert.LessOrEqual(t, n.x+n.width/2, l.width)
+		assert.GreaterOrEqual(t, n.y-n.height/2, 0.0)
+		assert.LessOrEqual(t, n.y+n.height/2, l.height)
+	}
+}
+
+func TestLayoutCrossings(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "C")
+	dag.AddEdge("A", "D")
+	dag.AddEdge("B", "E")
+	dag.AddEdge("B", "F")
+	dag.AddEdge("C", "G")
+	dag.AddEdge("F", "H")
+
+	l := newLayout(dag, labelData, "")
+	assertLayout(t, l)
+	assert.Equal(t, 0, l.crossings())
+
+	// Scramble the middle rank so that edges cross, then reorder
+	slices.Reverse(l.ranks[1])
+	l.updatePositions()
+	assert.Positive(t, l.crossings())
+	l.orderRanks()
+	assert.Equal(t, 0, l.crossings())
+}
+
+func TestLayoutDirections(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("A", "C")
+
+	a := func(l *layout[string]) *layoutNode[string] { return l.nodes[dag.Node("A")] }
+	b := func(l *layout[string]) *layoutNode[string] { return l.nodes[dag.Node("B")] }
+
+	l := newLayout(dag, labelData, "LR")
+	assertLayout(t, l)
+	assert.Less(t, a(l).x, b(l).x)
+
+	l = newLayout(dag, labelData, "BT")
+	assert.Greater(t, a(l).y, b(l).y)
+
+	l = newLayout(dag, labelData, "RL")
+	assert.Greater(t, a(l).x, b(l).x)
+}
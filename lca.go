@@ -0,0 +1,129 @@
+package dag
+
+import "slices"
+
+// LowestCommonAncestors returns the lowest common ancestors of the nodes with
+// the given data, like git merge-base: the nodes that are ancestors of all
+// of them, counting each node as its own ancestor, and that are not an
+// ancestor of any other such node. With more than two nodes, this is the
+// common ancestry of all of them, like git merge-base --octopus.
+//
+// A node may have several lowest common ancestors, such as after criss-cross
+// merges. They are returned in the DAG's node order. It returns nil if any
+// of the nodes does not exist or they have no common ancestor.
+func (d *DAG[T]) LowestCommonAncestors(a T, b ...T) []*Node[T] {
+	var common map[*Node[T]]struct{}
+	for _, data := range append([]T{a}, b...) {
+		node := d.nodes[data]
+		if node == nil {
+			return nil
+		}
+		ancestors := map[*Node[T]]struct{}{node: {}}
+		for _, ancestor := range d.Ancestors(data) {
+			ancestors[ancestor] = struct{}{}
+		}
+		if common == nil {
+			common = ancestors
+			continue
+		}
+		for node := range common {
+			if _, shared := ancestors[node]; !shared {
+				delete(common, node)
+			}
+		}
+	}
+	return d.lowest(common)
+}
+
+// lowest returns the members of a set of common ancestors that have no child
+// in the set. Every node on a path between two common ancestors is itself a
+// common ancestor, so checking children is enough.
+func (d *DAG[T]) lowest(common map[*Node[T]]struct{}) []*Node[T] {
+	var result []*Node[T]
+	for node := range common {
+		isLowest := true
+		for child := range node.children {
+			if _, shared := common[child]; shared {
+				isLowest = false
+				break
+			}
+		}
+		if isLowest {
+			result = append(result, node)
+		}
+	}
+	d.order.sort(result)
+	return result
+}
+
+// LCAIndex answers lowest common ancestor queries on a CompiledDAG in time
+// proportional to the number of nodes divided by 64, by precomputing the set
+// of ancestors of every node as a bitset. It needs O(V²) bits of memory, so
+// it suits graphs of up to tens of thousands of nodes that are queried many
+// times. It is safe for concurrent use.
+type LCAIndex[T comparable] struct {
+	graph *CompiledDAG[T]
+	// ancestors[id] holds the ancestors of node id, including itself.
+	ancestors []bitset
+}
+
+// LCAIndex builds an LCAIndex for the graph.
+func (c *CompiledDAG[T]) LCAIndex() *LCAIndex[T] {
+	n := len(c.data)
+	ancestors := make([]bitset, n)
+	for _, id := range c.topoOrder() {
+		set := newBitset(n)
+		set.set(id)
+		for _, parent := range c.parentsOf(id) {
+			set.or(ancestors[parent])
+		}
+		ancestors[id] = set
+	}
+	return &LCAIndex[T]{graph: c, ancestors: ancestors}
+}
+
+// LowestCommonAncestors returns the data of the lowest common ancestors of
+// the nodes with the given data, as described for DAG.LowestCommonAncestors,
+// in ID order. It returns nil if any of the nodes does not exist or they
+// have no common ancestor.
+func (x *LCAIndex[T]) LowestCommonAncestors(a T, b ...T) []T {
+	c := x.graph
+	var common bitset
+	for _, data := range append([]T{a}, b...) {
+		id, exists := c.ids[data]
+		if !exists {
+			return nil
+		}
+		if common == nil {
+			common = slices.Clone(x.ancestors[id])
+		} else {
+			common.and(x.ancestors[id])
+		}
+	}
+
+	var result []int
+	for id := range c.data {
+		if !common.has(id) {
+			continue
+		}
+		isLowest := true
+		for _, child := range c.childrenOf(id) {
+			if common.has(child) {
+				isLowest = false
+				break
+			}
+		}
+		if isLowest {
+			result = append(result, id)
+		}
+	}
+	return c.lookup(result)
+}
+
+// IsAncestor reports whether the node with data 'ancestor' is an ancestor of
+// the node with data 'node', counting a node as its own ancestor.
+func (x *LCAIndex[T]) IsAncestor(ancestor, node T) bool {
+	ancestorID, ancestorExists := x.graph.ids[ancestor]
+	id, exists := x.graph.ids[node]
+	return ancestorExists && exists && x.ancestors[id].has(ancestorID)
+}
@@ -0,0 +1,96 @@
+package dag
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// criss-cross history: A and B are both merged into C and D
+func newCrissCross() *DAG[string] {
+	dag := NewDAG[string]()
+	dag.AddEdge("R", "A")
+	dag.AddEdge("R", "B")
+	dag.AddEdge("A", "C")
+	dag.AddEdge("B", "C")
+	dag.AddEdge("A", "D")
+	dag.AddEdge("B", "D")
+	dag.AddEdge("C", "E")
+	dag.AddEdge("D", "F")
+	dag.AddNode("X")
+	return dag
+}
+
+func TestLowestCommonAncestors(t *testing.T) {
+	dag := newCrissCross()
+
+	tests := []struct {
+		nodes    []string
+		expected []string
+	}{
+		{[]string{"E", "F"}, []string{"A", "B"}},
+		{[]string{"C", "D"}, []string{"A", "B"}},
+		{[]string{"A", "B"}, []string{"R"}},
+		{[]string{"A", "E"}, []string{"A"}},
+		{[]string{"E", "A"}, []string{"A"}},
+		{[]string{"E", "E"}, []string{"E"}},
+		{[]string{"E"}, []string{"E"}},
+		{[]string{"E", "F", "A"}, []string{"A"}},
+		{[]string{"E", "X"}, nil},
+		{[]string{"E", "missing"}, nil},
+	}
+
+	index := dag.Compile().LCAIndex()
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, nodeData(dag.LowestCommonAncestors(tt.nodes[0], tt.nodes[1:]...)), "Unexpected result for %v", tt.nodes)
+		assert.Equal(t, tt.expected, index.LowestCommonAncestors(tt.nodes[0], tt.nodes[1:]...), "Unexpected indexed result for %v", tt.nodes)
+	}
+}
+
+func TestLCAIndexIsAncestor(t *testing.T) {
+	index := newCrissCross().Compile().LCAIndex()
+	assert.True(t, index.IsAncestor("R", "E"))
+	assert.True(t, index.IsAncestor("E", "E"))
+	assert.False(t, index.IsAncestor("E", "R"))
+	assert.False(t, index.IsAncestor("C", "F"))
+	assert.False(t, index.IsAncestor("missing", "E"))
+}
+
+func TestLCAIndexRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	dag := NewDAG(WithOrdered[int]())
+	for i := 0; i < 300; i++ {
+		from, to := r.Intn(100), r.Intn(100)
+		if from < to {
+			dag.AddEdge(from, to)
+		}
+	}
+
+	index := dag.Compile().LCAIndex()
+	for i := 0; i < 200; i++ {
+		a, b := r.Intn(100), r.Intn(100)
+		assert.Equal(t, nodeData(dag.LowestCommonAncestors(a, b)), index.LowestCommonAncestors(a, b), "Unexpected result for %d, %d", a, b)
+	}
+}
+
+func BenchmarkLowestCommonAncestors(b *testing.B) {
+	dag := NewDAG(WithOrdered[int]())
+	for _, edge := range benchmarkEdges(benchmarkNodes) {
+		dag.AddEdge(edge[0], edge[1])
+	}
+	for b.Loop() {
+		dag.LowestCommonAncestors(benchmarkNodes-1, benchmarkNodes-2)
+	}
+}
+
+func BenchmarkLCAIndex(b *testing.B) {
+	dag := NewDAG(WithOrdered[int]())
+	for _, edge := range benchmarkEdges(benchmarkNodes) {
+		dag.AddEdge(edge[0], edge[1])
+	}
+	index := dag.Compile().LCAIndex()
+	for b.Loop() {
+		index.LowestCommonAncestors(benchmarkNodes-1, benchmarkNodes-2)
+	}
+}
@@ -1,16 +1,25 @@
 package dag
 
-import (
-	"fmt"
-	"sort"
-)
-
 // Node represents a node in the DAG.
 type Node[T comparable] struct {
 	data T
 
-	parents  map[*Node[T]]struct{}
-	children map[*Node[T]]struct{}
+	// order is shared with the owning DAG and seq records when the node
+	// was added to it, for ordering by insertion.
+	order *ordering[T]
+	seq   uint64
+	// ord is the node's position in a topological order of the owning DAG,
+	// maintained incrementally as edges are added.
+	ord uint64
+
+	parents map[*Node[T]]struct{}
+	// children maps each child to the weight of the edge leading to it.
+	children map[*Node[T]]float64
+
+	attrs Attributes
+	// edgeAttrs holds the attributes of edges to children, created when
+	// first accessed.
+	edgeAttrs map[*Node[T]]*Attributes
 }
 
 // NewNode creates and returns a new Node with the given data.
@@ -18,7 +27,7 @@ func NewNode[T comparable](data T) *Node[T] {
 	return &Node[T]{
 		data:     data,
 		parents:  make(map[*Node[T]]struct{}),
-		children: make(map[*Node[T]]struct{}),
+		children: make(map[*Node[T]]float64),
 	}
 }
 
@@ -27,29 +36,23 @@ func (n *Node[T]) Data() T {
 	return n.data
 }
 
-// Parents returns the parent nodes of the current node in deterministic order.
+// Parents returns the parent nodes of the current node in the DAG's node order.
 func (n *Node[T]) Parents() []*Node[T] {
 	parents := make([]*Node[T], 0, len(n.parents))
 	for parent := range n.parents {
 		parents = append(parents, parent)
 	}
-	// Sort by string representation of node data for deterministic ordering
-	sort.Slice(parents, func(i, j int) bool {
-		return fmt.Sprintf("%v", parents[i].data) < fmt.Sprintf("%v", parents[j].data)
-	})
+	n.order.sort(parents)
 	return parents
 }
 
-// Children returns the child nodes of the current node in deterministic order.
+// Children returns the child nodes of the current node in the DAG's node order.
 func (n *Node[T]) Children() []*Node[T] {
 	children := make([]*Node[T], 0, len(n.children))
 	for child := range n.children {
 		children = append(children, child)
 	}
-	// Sort by string representation of node data for deterministic ordering
-	sort.Slice(children, func(i, j int) bool {
-		return fmt.Sprintf("%v", children[i].data) < fmt.Sprintf("%v", children[j].data)
-	})
+	n.order.sort(children)
 	return children
 }
 
@@ -57,16 +60,30 @@ func (n *Node[T]) addParent(parent *Node[T]) {
 	n.parents[parent] = struct{}{}
 }
 
+// addChild adds an edge to child with DefaultEdgeWeight,
+// leaving the weight of an existing edge unchanged.
 func (n *Node[T]) addChild(child *Node[T]) {
-	n.children[child] = struct{}{}
+	if _, exists := n.children[child]; !exists {
+		n.children[child] = DefaultEdgeWeight
+	}
 }
 
 // hasAncestor checks if the current node has the specified ancestor node.
 // It performs a depth-first search to determine the relationship.
 func (n *Node[T]) hasAncestor(ancestor *Node[T]) bool {
+	return n.pathFromAncestor(ancestor) != nil
+}
+
+// pathFromAncestor returns the nodes on a path from the specified ancestor
+// down to the current node, inclusive of both ends, or nil if ancestor is not
+// an ancestor of the current node. It performs a depth-first search over
+// parents, so the path returned is the first one found in deterministic order.
+func (n *Node[T]) pathFromAncestor(ancestor *Node[T]) []*Node[T] {
 	visited := make(map[*Node[T]]struct{})
+	var path []*Node[T]
 	var visit func(node *Node[T]) bool
 	visit = func(node *Node[T]) bool {
+		path = append(path, node)
 		if node == ancestor {
 			return true
 		}
@@ -79,7 +96,15 @@ func (n *Node[T]) hasAncestor(ancestor *Node[T]) bool {
 				}
 			}
 		}
+		path = path[:len(path)-1]
 		return false
 	}
-	return visit(n)
+	if !visit(n) {
+		return nil
+	}
+	// The search walked upwards, so reverse to get ancestor-first order.
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
 }
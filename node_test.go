@@ -126,3 +126,23 @@ func Test_hasAncestor(t *testing.T) {
 	assert.False(t, node2.hasAncestor(node3), "Node 3 should not be an ancestor of Node 2")
 	assert.False(t, node4.hasAncestor(node2), "Node 2 should not be an ancestor of Node 4")
 }
+
+func Test_pathFromAncestor(t *testing.T) {
+	dag := NewDAG[int]()
+
+	// Create edges: 1 -> 2 -> 3 and 1 -> 4 -> 3
+	dag.AddEdge(1, 2)
+	dag.AddEdge(2, 3)
+	dag.AddEdge(1, 4)
+	dag.AddEdge(4, 3)
+
+	node1 := dag.nodes[1]
+	node2 := dag.nodes[2]
+	node3 := dag.nodes[3]
+	node4 := dag.nodes[4]
+
+	assert.Equal(t, []*Node[int]{node1, node2, node3}, node3.pathFromAncestor(node1), "Expected first path in deterministic order")
+	assert.Equal(t, []*Node[int]{node4, node3}, node3.pathFromAncestor(node4))
+	assert.Equal(t, []*Node[int]{node3}, node3.pathFromAncestor(node3), "A node should be a path to itself")
+	assert.Nil(t, node2.pathFromAncestor(node4), "Node 4 should not be an ancestor of Node 2")
+}
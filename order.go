@@ -0,0 +1,67 @@
+package dag
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// Option configures a DAG at construction time.
+type Option[T comparable] func(d *DAG[T])
+
+// WithCompare orders nodes using compare, which returns a negative number
+// when a sorts before b, a positive number when a sorts after b, and zero
+// when they are equal, as with cmp.Compare. Nodes that compare equal are
+// ordered by when they were first added to the DAG.
+func WithCompare[T comparable](compare func(a, b T) int) Option[T] {
+	return func(d *DAG[T]) {
+		d.order = &ordering[T]{compare: compare}
+	}
+}
+
+// WithOrdered orders nodes by the natural order of T using cmp.Compare,
+// so that numeric data sorts numerically.
+func WithOrdered[T cmp.Ordered]() Option[T] {
+	return WithCompare(cmp.Compare[T])
+}
+
+// WithInsertionOrder orders nodes by when they were first added to the DAG.
+func WithInsertionOrder[T comparable]() Option[T] {
+	return func(d *DAG[T]) {
+		d.order = &ordering[T]{insertion: true}
+	}
+}
+
+// ordering determines the deterministic order in which nodes are returned
+// by Children, Parents and every traversal built on them. A nil ordering
+// sorts by the string representation of node data.
+type ordering[T comparable] struct {
+	compare   func(a, b T) int
+	insertion bool
+}
+
+// sort sorts nodes in place.
+func (o *ordering[T]) sort(nodes []*Node[T]) {
+	if len(nodes) < 2 {
+		return
+	}
+	switch {
+	case o != nil && o.insertion:
+		slices.SortFunc(nodes, func(a, b *Node[T]) int {
+			return cmp.Compare(a.seq, b.seq)
+		})
+	case o != nil && o.compare != nil:
+		slices.SortStableFunc(nodes, func(a, b *Node[T]) int {
+			return cmp.Or(o.compare(a.data, b.data), cmp.Compare(a.seq, b.seq))
+		})
+	default:
+		// Format each node once rather than on every comparison
+		keys := make(map[*Node[T]]string, len(nodes))
+		for _, node := range nodes {
+			keys[node] = fmt.Sprintf("%v", node.data)
+		}
+		slices.SortStableFunc(nodes, func(a, b *Node[T]) int {
+			return cmp.Compare(keys[a], keys[b])
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package dag
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultOrder(t *testing.T) {
+	dag := NewDAG[int]()
+	dag.AddEdge(1, 9)
+	dag.AddEdge(1, 10)
+	dag.AddNode(2)
+
+	// The default order compares the string representation
+	assert.Equal(t, []int{10, 9}, nodeData(dag.Node(1).Children()))
+	assert.Equal(t, []int{1, 10, 2, 9}, nodeData(dag.Nodes()))
+	assert.Equal(t, []int{1, 2}, nodeData(dag.Roots()))
+}
+
+func TestWithOrdered(t *testing.T) {
+	dag := NewDAG(WithOrdered[int]())
+	dag.AddEdge(1, 10)
+	dag.AddEdge(1, 9)
+	dag.AddEdge(10, 11)
+	dag.AddEdge(9, 11)
+	dag.AddNode(0)
+
+	assert.Equal(t, []int{9, 10}, nodeData(dag.Node(1).Children()))
+	assert.Equal(t, []int{9, 10}, nodeData(dag.Node(11).Parents()))
+	assert.Equal(t, []int{0, 1, 9, 10, 11}, nodeData(dag.Nodes()))
+	assert.Equal(t, []int{0, 1}, nodeData(dag.Roots()))
+
+	sorted, _ := dag.Traverse()
+	assert.Equal(t, []int{0, 1, 9, 10, 11}, nodeData(sorted))
+
+	var walked []int
+	dag.Walk(1, func(v int) { walked = append(walked, v) })
+	assert.Equal(t, []int{1, 9, 11, 10}, walked)
+
+	var edges [][2]int
+	for _, edge := range dag.Edges() {
+		edges = append(edges, [2]int{edge[0].Data(), edge[1].Data()})
+	}
+	assert.Equal(t, [][2]int{{1, 9}, {1, 10}, {9, 11}, {10, 11}}, edges)
+
+	assert.Equal(t, "digraph G {\n"+
+		"    \"1\" -> \"9\";\n"+
+		"    \"1\" -> \"10\";\n"+
+		"    \"9\" -> \"11\";\n"+
+		"    \"10\" -> \"11\";\n"+
+		"}\n", dag.Visualize())
+}
+
+func TestWithCompare(t *testing.T) {
+	// Order case-insensitively, in reverse
+	dag := NewDAG(WithCompare(func(a, b string) int {
+		return strings.Compare(strings.ToLower(b), strings.ToLower(a))
+	}))
+	dag.AddEdge("root", "a")
+	dag.AddEdge("root", "B")
+	dag.AddEdge("root", "c")
+
+	assert.Equal(t, []string{"c", "B", "a"}, nodeData(dag.Node("root").Children()))
+
+	levels := dag.LevelOrder()
+	assert.Equal(t, []string{"c", "B", "a"}, nodeData(levels[1]))
+}
+
+func TestWithInsertionOrder(t *testing.T) {
+	dag := NewDAG(WithInsertionOrder[string]())
+	dag.AddEdge("z", "y")
+	dag.AddEdge("z", "x")
+	dag.AddEdge("a", "x")
+
+	assert.Equal(t, []string{"z", "y", "x", "a"}, nodeData(dag.Nodes()))
+	assert.Equal(t, []string{"y", "x"}, nodeData(dag.Node("z").Children()))
+	assert.Equal(t, []string{"z", "a"}, nodeData(dag.Node("x").Parents()))
+	assert.Equal(t, []string{"z", "a"}, nodeData(dag.Roots()))
+
+	sorted, _ := dag.Traverse()
+	assert.Equal(t, []string{"z", "a", "y", "x"}, nodeData(sorted))
+
+	// Derived graphs keep the ordering and the insertion sequence
+	reduced := dag.TransitiveReduction()
+	assert.Equal(t, []string{"z", "y", "x", "a"}, nodeData(reduced.Nodes()))
+
+	// Decoding into a DAG keeps its ordering
+	data, err := json.Marshal(dag)
+	assert.NoError(t, err)
+	decoded := NewDAG(WithInsertionOrder[string]())
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	assert.Equal(t, []string{"z", "y", "x", "a"}, nodeData(decoded.Nodes()))
+}
+
+func TestWithCompareTies(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	dag := NewDAG(WithCompare(func(a, b task) int {
+		return cmp.Compare(a.priority, b.priority)
+	}))
+	root := task{"root", 0}
+	var expected []task
+	for i := range 20 {
+		child := task{fmt.Sprintf("t%d", i), i % 2}
+		dag.AddEdge(root, child)
+		if child.priority == 0 {
+			expected = append(expected, child)
+		}
+	}
+	for i := range 20 {
+		if i%2 == 1 {
+			expected = append(expected, task{fmt.Sprintf("t%d", i), 1})
+		}
+	}
+
+	// Ties fall back to insertion order, so repeated calls agree
+	for range 10 {
+		assert.Equal(t, expected, nodeData(dag.Node(root).Children()))
+	}
+	sorted, _ := dag.Traverse()
+	assert.Equal(t, append([]task{root}, expected...), nodeData(sorted))
+}
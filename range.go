@@ -0,0 +1,256 @@
+package dag
+
+import (
+	"container/heap"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// RevRange selects nodes by reachability, like a git revision range. A node
+// is reachable from another if it is that node or one of its ancestors.
+//
+// The range holds every node reachable from any of Include but from none of
+// Exclude. If Symmetric is set, nodes reachable from all of Include are also
+// left out, which for two nodes gives their symmetric difference.
+type RevRange[T comparable] struct {
+	Include   []T
+	Exclude   []T
+	Symmetric bool
+}
+
+// ParseRevRange parses a git-style range expression made of space separated
+// terms, where node names must not contain spaces or "..":
+//
+//	B         include B
+//	^A        exclude A
+//	A..B      include B and exclude A, same as "B ^A"
+//	A...B     include A and B, excluding their common ancestors
+//
+// A symmetric range cannot be combined with other included nodes.
+func ParseRevRange(expr string) (RevRange[string], error) {
+	var r RevRange[string]
+	terms := strings.Fields(expr)
+	if len(terms) == 0 {
+		return r, fmt.Errorf("empty range expression")
+	}
+	for _, term := range terms {
+		switch {
+		case strings.HasPrefix(term, "^"):
+			if term == "^" {
+				return r, fmt.Errorf("range term %q: missing node", term)
+			}
+			r.Exclude = append(r.Exclude, term[1:])
+		case strings.Contains(term, "..."):
+			a, b, _ := strings.Cut(term, "...")
+			if a == "" || b == "" || strings.Contains(b, "..") {
+				return r, fmt.Errorf("range term %q: expected A...B", term)
+			}
+			r.Include = append(r.Include, a, b)
+			r.Symmetric = true
+		case strings.Contains(term, ".."):
+			a, b, _ := strings.Cut(term, "..")
+			if a == "" || b == "" || strings.Contains(b, "..") {
+				return r, fmt.Errorf("range term %q: expected A..B", term)
+			}
+			r.Exclude = append(r.Exclude, a)
+			r.Include = append(r.Include, b)
+		default:
+			r.Include = append(r.Include, term)
+		}
+	}
+	if r.Symmetric && len(r.Include) != 2 {
+		return r, fmt.Errorf("range %q: A...B cannot be combined with other included nodes", expr)
+	}
+	return r, nil
+}
+
+// RangeOrder selects the order in which Range returns nodes.
+type RangeOrder int
+
+const (
+	// OrderTopological returns every node before its children.
+	OrderTopological RangeOrder = iota
+	// OrderReverseTopological returns every node before its parents, like
+	// git log.
+	OrderReverseTopological
+	// OrderPriority returns nodes sorted by RangeOptions.Compare alone,
+	// ignoring edges.
+	OrderPriority
+)
+
+// RangeOptions configures the output of Range.
+type RangeOptions[T comparable] struct {
+	Order RangeOrder
+	// Compare orders nodes, such as by timestamp. For the topological orders
+	// it decides between nodes that are not constrained by an edge. Nodes it
+	// considers equal, or all nodes if it is nil, follow the DAG's node order.
+	Compare func(a, b T) int
+}
+
+// Range returns the nodes selected by r in the order chosen by opts.
+// It returns nil if any of the nodes named in r does not exist.
+func (d *DAG[T]) Range(r RevRange[T], opts RangeOptions[T]) []*Node[T] {
+	include, ok := d.lookupNodes(r.Include)
+	if !ok {
+		return nil
+	}
+	exclude, ok := d.lookupNodes(r.Exclude)
+	if !ok {
+		return nil
+	}
+	if r.Symmetric && len(r.Include) > 0 {
+		exclude = append(exclude, d.LowestCommonAncestors(r.Include[0], r.Include[1:]...)...)
+	}
+
+	// Everything reachable from an excluded node is excluded, so the search
+	// from the included nodes can stop there
+	excluded := make(map[*Node[T]]struct{})
+	walkFrom(exclude, false, func(node *Node[T]) bool {
+		excluded[node] = struct{}{}
+		return true
+	})
+	selected := make(map[*Node[T]]struct{})
+	walkFrom(include, false, func(node *Node[T]) bool {
+		if _, skip := excluded[node]; skip {
+			return false
+		}
+		selected[node] = struct{}{}
+		return true
+	})
+
+	return d.orderRange(selected, opts)
+}
+
+// lookupNodes returns the nodes with the given data, and false if any of
+// them does not exist.
+func (d *DAG[T]) lookupNodes(data []T) ([]*Node[T], bool) {
+	nodes := make([]*Node[T], 0, len(data))
+	for _, v := range data {
+		node := d.nodes[v]
+		if node == nil {
+			return nil, false
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, true
+}
+
+// walkFrom visits the given nodes and their descendants, or their ancestors
+// if forward is false, once each, not continuing past nodes for which visit
+// returns false.
+func walkFrom[T comparable](start []*Node[T], forward bool, visit func(node *Node[T]) bool) {
+	seen := make(map[*Node[T]]struct{})
+	stack := append([]*Node[T](nil), start...)
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, done := seen[node]; done {
+			continue
+		}
+		seen[node] = struct{}{}
+		if !visit(node) {
+			continue
+		}
+		if forward {
+			for child := range node.children {
+				stack = append(stack, child)
+			}
+		} else {
+			for parent := range node.parents {
+				stack = append(stack, parent)
+			}
+		}
+	}
+}
+
+// orderRange returns the selected nodes in the order chosen by opts.
+func (d *DAG[T]) orderRange(selected map[*Node[T]]struct{}, opts RangeOptions[T]) []*Node[T] {
+	if len(selected) == 0 {
+		return nil
+	}
+	nodes := make([]*Node[T], 0, len(selected))
+	for node := range selected {
+		nodes = append(nodes, node)
+	}
+	d.order.sort(nodes)
+	rank := make(map[*Node[T]]int, len(nodes))
+	for i, node := range nodes {
+		rank[node] = i
+	}
+	less := func(a, b *Node[T]) bool {
+		if opts.Compare != nil {
+			if c := opts.Compare(a.data, b.data); c != 0 {
+				return c < 0
+			}
+		}
+		return rank[a] < rank[b]
+	}
+
+	if opts.Order == OrderPriority {
+		// Stable, so that ties keep the node order
+		slices.SortStableFunc(nodes, func(a, b *Node[T]) int {
+			if opts.Compare == nil {
+				return 0
+			}
+			return opts.Compare(a.data, b.data)
+		})
+		return nodes
+	}
+
+	// Kahn's algorithm within the selection, always taking the first ready
+	// node by priority
+	parents := func(node *Node[T]) iter.Seq[*Node[T]] { return maps.Keys(node.parents) }
+	children := func(node *Node[T]) iter.Seq[*Node[T]] { return maps.Keys(node.children) }
+	in, out := parents, children
+	if opts.Order == OrderReverseTopological {
+		in, out = children, parents
+	}
+	degree := make(map[*Node[T]]int, len(nodes))
+	ready := &nodeHeap[T]{less: less}
+	for _, node := range nodes {
+		for prev := range in(node) {
+			if _, ok := selected[prev]; ok {
+				degree[node]++
+			}
+		}
+		if degree[node] == 0 {
+			ready.nodes = append(ready.nodes, node)
+		}
+	}
+	heap.Init(ready)
+
+	sorted := make([]*Node[T], 0, len(nodes))
+	for ready.Len() > 0 {
+		node := heap.Pop(ready).(*Node[T])
+		sorted = append(sorted, node)
+		for next := range out(node) {
+			if _, ok := selected[next]; !ok {
+				continue
+			}
+			degree[next]--
+			if degree[next] == 0 {
+				heap.Push(ready, next)
+			}
+		}
+	}
+	return sorted
+}
+
+// nodeHeap is a priority queue of nodes for container/heap.
+type nodeHeap[T comparable] struct {
+	nodes []*Node[T]
+	less  func(a, b *Node[T]) bool
+}
+
+func (h *nodeHeap[T]) Len() int           { return len(h.nodes) }
+func (h *nodeHeap[T]) Less(i, j int) bool { return h.less(h.nodes[i], h.nodes[j]) }
+func (h *nodeHeap[T]) Swap(i, j int)      { h.nodes[i], h.nodes[j] = h.nodes[j], h.nodes[i] }
+func (h *nodeHeap[T]) Push(x any)         { h.nodes = append(h.nodes, x.(*Node[T])) }
+func (h *nodeHeap[T]) Pop() any {
+	node := h.nodes[len(h.nodes)-1]
+	h.nodes = h.nodes[:len(h.nodes)-1]
+	return node
+}
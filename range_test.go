@@ -0,0 +1,104 @@
+package dag
+
+import (
+	"cmp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newHistory builds a revision history with a feature branch merged back:
+//
+//	R - A - B - C - M
+//	     \         /
+//	      X ---- Y
+func newHistory() *DAG[string] {
+	dag := NewDAG[string]()
+	dag.AddEdge("R", "A")
+	dag.AddEdge("A", "B")
+	dag.AddEdge("B", "C")
+	dag.AddEdge("A", "X")
+	dag.AddEdge("X", "Y")
+	dag.AddEdge("C", "M")
+	dag.AddEdge("Y", "M")
+	return dag
+}
+
+func TestParseRevRange(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected RevRange[string]
+	}{
+		{"C", RevRange[string]{Include: []string{"C"}}},
+		{"C ^Y", RevRange[string]{Include: []string{"C"}, Exclude: []string{"Y"}}},
+		{"Y..C", RevRange[string]{Include: []string{"C"}, Exclude: []string{"Y"}}},
+		{"Y...C", RevRange[string]{Include: []string{"Y", "C"}, Symmetric: true}},
+		{"Y...C ^A", RevRange[string]{Include: []string{"Y", "C"}, Exclude: []string{"A"}, Symmetric: true}},
+		{" M ^B  ^X ", RevRange[string]{Include: []string{"M"}, Exclude: []string{"B", "X"}}},
+	}
+	for _, tt := range tests {
+		r, err := ParseRevRange(tt.expr)
+		assert.NoError(t, err, "Unexpected error for %q", tt.expr)
+		assert.Equal(t, tt.expected, r, "Unexpected range for %q", tt.expr)
+	}
+
+	for _, expr := range []string{"", "^", "..C", "Y..", "A...", "A..B..C", "A...B C"} {
+		_, err := ParseRevRange(expr)
+		assert.Error(t, err, "Expected an error for %q", expr)
+	}
+}
+
+func TestRange(t *testing.T) {
+	dag := newHistory()
+
+	tests := []struct {
+		expr     string
+		expected []string
+	}{
+		{"C", []string{"R", "A", "B", "C"}},
+		{"C ^Y", []string{"B", "C"}},
+		{"Y..C", []string{"B", "C"}},
+		{"C..Y", []string{"X", "Y"}},
+		{"Y...C", []string{"B", "C", "X", "Y"}},
+		{"M ^C", []string{"X", "Y", "M"}},
+		{"B C ^A", []string{"B", "C"}},
+		{"C..B", nil},
+		{"C ^missing", nil},
+	}
+	for _, tt := range tests {
+		r, err := ParseRevRange(tt.expr)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, nodeData(dag.Range(r, RangeOptions[string]{})), "Unexpected nodes for %q", tt.expr)
+	}
+}
+
+func TestRangeOrder(t *testing.T) {
+	dag := newHistory()
+	r := RevRange[string]{Include: []string{"M"}, Exclude: []string{"A"}}
+
+	nodes := dag.Range(r, RangeOptions[string]{Order: OrderReverseTopological})
+	assert.Equal(t, []string{"M", "C", "B", "Y", "X"}, nodeData(nodes))
+
+	// Commit timestamps, with the feature branch interleaved
+	times := map[string]int{"X": 1, "B": 2, "Y": 3, "C": 4, "M": 5}
+	byTime := func(a, b string) int {
+		return cmp.Compare(times[a], times[b])
+	}
+	newestFirst := func(a, b string) int {
+		return -byTime(a, b)
+	}
+
+	nodes = dag.Range(r, RangeOptions[string]{Compare: byTime})
+	assert.Equal(t, []string{"X", "B", "Y", "C", "M"}, nodeData(nodes))
+
+	nodes = dag.Range(r, RangeOptions[string]{Order: OrderReverseTopological, Compare: newestFirst})
+	assert.Equal(t, []string{"M", "C", "Y", "B", "X"}, nodeData(nodes))
+
+	// Priority order ignores edges
+	times["X"] = 10
+	nodes = dag.Range(r, RangeOptions[string]{Order: OrderPriority, Compare: byTime})
+	assert.Equal(t, []string{"B", "Y", "C", "M", "X"}, nodeData(nodes))
+
+	nodes = dag.Range(r, RangeOptions[string]{Order: OrderPriority})
+	assert.Equal(t, []string{"B", "C", "M", "X", "Y"}, nodeData(nodes), "Expected node order without Compare")
+}
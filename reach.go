@@ -0,0 +1,254 @@
+package dag
+
+import (
+	"math/rand"
+	"slices"
+)
+
+// reachBitsetLimit is the largest number of nodes for which the reachability
+// index stores the full transitive closure, which takes V² bits.
+const reachBitsetLimit = 1 << 14
+
+// reachLabelings is the number of interval labelings used by the
+// reachability index for larger graphs.
+const reachLabelings = 3
+
+// WithReachabilityIndex enables the reachability index from the start,
+// as described for EnableReachabilityIndex.
+func WithReachabilityIndex[T comparable]() Option[T] {
+	return func(d *DAG[T]) {
+		d.reachIndexed = true
+	}
+}
+
+// EnableReachabilityIndex speeds up HasPath, Ancestors and Descendants on
+// graphs that are queried much more often than they change. The index is
+// built on the first query and reused until the DAG changes: adding an edge
+// between existing nodes updates it, while other changes discard it to be
+// rebuilt on the next query. Results are the same as without the index.
+//
+// For graphs of up to 16384 nodes, the index stores the transitive closure
+// as bitsets, so HasPath takes constant time. Larger graphs use interval
+// labels that answer most negative queries immediately and prune the search
+// for the rest. Ancestors and Descendants use adjacency lists cached in the
+// DAG's node order.
+func (d *DAG[T]) EnableReachabilityIndex() {
+	d.reachIndexed = true
+}
+
+// DisableReachabilityIndex discards the reachability index and stops
+// maintaining it.
+func (d *DAG[T]) DisableReachabilityIndex() {
+	d.reachIndexed = false
+	d.reach = nil
+}
+
+// reachIndex caches reachability information for a DAG. Nodes are numbered
+// in topological order when the index is built.
+type reachIndex[T comparable] struct {
+	ids   map[*Node[T]]int
+	nodes []*Node[T]
+	// children and parents list the neighbours of each node in the DAG's
+	// node order.
+	children, parents [][]int
+
+	// descendants holds the transitive closure, with each node counted as
+	// its own descendant. It is nil for graphs over reachBitsetLimit, which
+	// use labels instead.
+	descendants []bitset
+	// labels[k][id] is the interval assigned to a node by the k-th
+	// labeling. If a node reaches another, its interval contains the other's.
+	labels [][]interval
+}
+
+// interval is a closed range of post-order ranks.
+type interval struct {
+	low, high int
+}
+
+func (i interval) contains(other interval) bool {
+	return i.low <= other.low && other.high <= i.high
+}
+
+// reachability returns the reachability index, building it if needed, or nil
+// if it is not enabled. It is safe to call from concurrent readers.
+func (d *DAG[T]) reachability() *reachIndex[T] {
+	if !d.reachIndexed {
+		return nil
+	}
+	d.reachMu.Lock()
+	defer d.reachMu.Unlock()
+	if d.reach == nil {
+		d.reach = newReachIndex(d, len(d.nodes) <= reachBitsetLimit)
+	}
+	return d.reach
+}
+
+// invalidateReach discards the reachability index after a mutation.
+func (d *DAG[T]) invalidateReach() {
+	d.reach = nil
+}
+
+// newReachIndex builds a reachability index for the DAG, storing the full
+// closure if closure is set and interval labels otherwise.
+func newReachIndex[T comparable](d *DAG[T], closure bool) *reachIndex[T] {
+	sorted, _ := d.Traverse()
+	n := len(sorted)
+	x := &reachIndex[T]{
+		ids:      make(map[*Node[T]]int, n),
+		nodes:    sorted,
+		children: make([][]int, n),
+		parents:  make([][]int, n),
+	}
+	for id, node := range sorted {
+		x.ids[node] = id
+	}
+	for id, node := range sorted {
+		x.children[id] = x.lookup(node.Children())
+		x.parents[id] = x.lookup(node.Parents())
+	}
+
+	if closure {
+		x.descendants = make([]bitset, n)
+		for id := n - 1; id >= 0; id-- {
+			set := newBitset(n)
+			set.set(id)
+			for _, child := range x.children[id] {
+				set.or(x.descendants[child])
+			}
+			x.descendants[id] = set
+		}
+		return x
+	}
+
+	// Each labeling ranks nodes in the post-order of a depth-first search
+	// that visits roots and children in a different random order, then
+	// widens each interval to cover those of its children. A fixed seed
+	// keeps the index deterministic.
+	r := rand.New(rand.NewSource(1))
+	x.labels = make([][]interval, reachLabelings)
+	for k := range x.labels {
+		labels := make([]interval, n)
+		visited := newBitset(n)
+		rank := 0
+		var visit func(id int)
+		visit = func(id int) {
+			visited.set(id)
+			children := slices.Clone(x.children[id])
+			r.Shuffle(len(children), func(i, j int) { children[i], children[j] = children[j], children[i] })
+			for _, child := range children {
+				if !visited.has(child) {
+					visit(child)
+				}
+			}
+			rank++
+			labels[id] = interval{low: rank, high: rank}
+		}
+		for _, id := range r.Perm(n) {
+			if !visited.has(id) && len(x.parents[id]) == 0 {
+				visit(id)
+			}
+		}
+		for id := n - 1; id >= 0; id-- {
+			for _, child := range x.children[id] {
+				labels[id].low = min(labels[id].low, labels[child].low)
+			}
+		}
+		x.labels[k] = labels
+	}
+	return x
+}
+
+// lookup maps nodes to their IDs.
+func (x *reachIndex[T]) lookup(nodes []*Node[T]) []int {
+	ids := make([]int, len(nodes))
+	for i, node := range nodes {
+		ids[i] = x.ids[node]
+	}
+	return ids
+}
+
+// hasPath reports whether there is a path from one node to another.
+func (x *reachIndex[T]) hasPath(from, to int) bool {
+	if x.descendants != nil {
+		return x.descendants[from].has(to)
+	}
+	if from == to {
+		return true
+	}
+	if !x.mayReach(from, to) {
+		return false
+	}
+
+	// Search from 'from', skipping nodes that the labels or the topological
+	// order rule out
+	visited := newBitset(len(x.nodes))
+	stack := []int{from}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, child := range x.children[id] {
+			if child == to {
+				return true
+			}
+			if !visited.has(child) && child < to && x.mayReach(child, to) {
+				visited.set(child)
+				stack = append(stack, child)
+			}
+		}
+	}
+	return false
+}
+
+// mayReach reports whether every labeling allows a path between two nodes.
+// A false result means there is no path.
+func (x *reachIndex[T]) mayReach(from, to int) bool {
+	for _, labels := range x.labels {
+		if !labels[from].contains(labels[to]) {
+			return false
+		}
+	}
+	return true
+}
+
+// reach returns the nodes reachable from start through next, excluding start,
+// in the same depth-first pre-order as Ancestors and Descendants.
+func (x *reachIndex[T]) reach(start int, next [][]int) []*Node[T] {
+	var reached []*Node[T]
+	visited := newBitset(len(x.nodes))
+	var visit func(id int)
+	visit = func(id int) {
+		for _, neighbour := range next[id] {
+			if !visited.has(neighbour) {
+				visited.set(neighbour)
+				reached = append(reached, x.nodes[neighbour])
+				visit(neighbour)
+			}
+		}
+	}
+	visit(start)
+	return reached
+}
+
+// addEdge updates the index for a new edge between nodes that are already
+// indexed, returning false if it must be rebuilt instead. Only the closure
+// can be updated in place; interval labels are always rebuilt.
+func (x *reachIndex[T]) addEdge(from, to *Node[T]) bool {
+	fromID, fromIndexed := x.ids[from]
+	toID, toIndexed := x.ids[to]
+	if !fromIndexed || !toIndexed || x.descendants == nil {
+		return false
+	}
+
+	x.children[fromID] = x.lookup(from.Children())
+	x.parents[toID] = x.lookup(to.Parents())
+
+	// Everything that reaches 'from' now also reaches what 'to' reaches
+	reached := x.descendants[toID]
+	for _, set := range x.descendants {
+		if set.has(fromID) && !set.has(toID) {
+			set.or(reached)
+		}
+	}
+	return true
+}
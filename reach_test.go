@@ -0,0 +1,139 @@
+package dag
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertSameReachability asserts that indexed answers the same reachability
+// queries as plain, which has the same nodes and edges but no index.
+func assertSameReachability(t *testing.T, indexed, plain *DAG[int], nodes int) {
+	t.Helper()
+	for from := 0; from < nodes; from++ {
+		assert.Equal(t, nodeData(plain.Ancestors(from)), nodeData(indexed.Ancestors(from)), "Unexpected ancestors of %d", from)
+		assert.Equal(t, nodeData(plain.Descendants(from)), nodeData(indexed.Descendants(from)), "Unexpected descendants of %d", from)
+		for to := 0; to < nodes; to++ {
+			assert.Equal(t, plain.HasPath(from, to), indexed.HasPath(from, to), "Unexpected HasPath(%d, %d)", from, to)
+		}
+	}
+}
+
+func TestReachabilityIndex(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const nodes = 60
+	indexed := NewDAG(WithOrdered[int](), WithReachabilityIndex[int]())
+	plain := NewDAG(WithOrdered[int]())
+
+	for round := 0; round < 5; round++ {
+		for i := 0; i < 40; i++ {
+			from, to := r.Intn(nodes), r.Intn(nodes)
+			assert.Equal(t, plain.AddEdge(from, to) == nil, indexed.AddEdge(from, to) == nil)
+		}
+		assertSameReachability(t, indexed, plain, nodes)
+		assert.NotNil(t, indexed.reach, "Expected the index to be in use")
+
+		for i := 0; i < 5; i++ {
+			edges := plain.Edges()
+			edge := edges[r.Intn(len(edges))]
+			plain.RemoveEdge(edge[0].Data(), edge[1].Data())
+			indexed.RemoveEdge(edge[0].Data(), edge[1].Data())
+		}
+		plain.RemoveNode(round)
+		indexed.RemoveNode(round)
+		assertSameReachability(t, indexed, plain, nodes)
+	}
+}
+
+func TestReachabilityIndexIncremental(t *testing.T) {
+	dag := NewDAG[string](WithReachabilityIndex[string]())
+	dag.AddEdge("A", "B")
+	dag.AddEdge("C", "D")
+	assert.False(t, dag.HasPath("A", "D"))
+
+	// An edge that agrees with the indexed order updates the index in place
+	index := dag.reach
+	assert.NoError(t, dag.AddEdge("B", "C"))
+	assert.Same(t, index, dag.reach)
+	assert.True(t, dag.HasPath("A", "D"))
+	assert.Equal(t, []string{"C", "B", "A"}, nodeData(dag.Ancestors("D")))
+
+	// Other mutations discard it
+	dag.RemoveEdge("B", "C")
+	assert.Nil(t, dag.reach)
+	assert.False(t, dag.HasPath("A", "D"))
+
+	err := dag.Update(func(tx *Tx[string]) error {
+		tx.AddEdge("D", "A")
+		assert.True(t, tx.DAG().HasPath("C", "B"))
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.False(t, dag.HasPath("C", "B"), "Expected the index to reflect the rollback")
+
+	dag.DisableReachabilityIndex()
+	assert.True(t, dag.HasPath("D", "D"))
+	assert.Nil(t, dag.reach)
+}
+
+func TestReachabilityLabels(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	const nodes = 80
+	dag := NewDAG(WithOrdered[int]())
+	for i := 0; i < 200; i++ {
+		dag.AddEdge(r.Intn(nodes), r.Intn(nodes))
+	}
+
+	// Force the labeling used for large graphs
+	closure := newReachIndex(dag, true)
+	labels := newReachIndex(dag, false)
+	assert.Nil(t, labels.descendants)
+	for from := 0; from < nodes; from++ {
+		for to := 0; to < nodes; to++ {
+			fromID, toID := closure.ids[dag.Node(from)], closure.ids[dag.Node(to)]
+			assert.Equal(t, closure.hasPath(fromID, toID), labels.hasPath(fromID, toID), "Unexpected HasPath(%d, %d)", from, to)
+		}
+	}
+}
+
+func TestReachabilityIndexConcurrent(t *testing.T) {
+	s := NewSyncDAG(WithOrdered[int](), WithReachabilityIndex[int]())
+	for i := 0; i < 50; i++ {
+		s.AddEdge(i, i+1)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.True(t, s.HasPath(0, 50))
+			assert.Len(t, s.Descendants(10), 40)
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkHasPath(b *testing.B) {
+	for _, indexed := range []bool{false, true} {
+		name := "DFS"
+		if indexed {
+			name = "Indexed"
+		}
+		b.Run(name, func(b *testing.B) {
+			dag := NewDAG(WithOrdered[int]())
+			if indexed {
+				dag.EnableReachabilityIndex()
+			}
+			for _, edge := range benchmarkEdges(benchmarkNodes) {
+				dag.AddEdge(edge[0], edge[1])
+			}
+			r := rand.New(rand.NewSource(1))
+			for b.Loop() {
+				dag.HasPath(r.Intn(benchmarkNodes), r.Intn(benchmarkNodes))
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package dag
+
+import "time"
+
+// Task is the computed schedule of a single node.
+type Task[T comparable] struct {
+	Data     T
+	Duration time.Duration
+
+	// EarliestStart and EarliestFinish are the earliest times the node can
+	// start and finish once all of its ancestors have finished.
+	EarliestStart  time.Duration
+	EarliestFinish time.Duration
+
+	// LatestStart and LatestFinish are the latest times the node can start
+	// and finish without delaying the overall schedule.
+	LatestStart  time.Duration
+	LatestFinish time.Duration
+
+	// Slack is how long the node can be delayed without delaying the
+	// overall schedule. Nodes with no slack are on a critical path.
+	Slack time.Duration
+}
+
+// Critical reports whether the task has no slack.
+func (t *Task[T]) Critical() bool {
+	return t.Slack == 0
+}
+
+// Schedule is the result of a critical path analysis.
+type Schedule[T comparable] struct {
+	// Tasks holds the schedule of every node in topological order.
+	Tasks []*Task[T]
+	// Duration is the total time needed to finish every node.
+	Duration time.Duration
+	// CriticalPath is a longest chain of dependent nodes,
+	// which determines Duration.
+	CriticalPath []T
+
+	tasks map[T]*Task[T]
+}
+
+// Task returns the schedule of the node with the given data, or nil if it
+// doesn't exist.
+func (s *Schedule[T]) Task(data T) *Task[T] {
+	return s.tasks[data]
+}
+
+// CriticalPath performs a critical path analysis (CPM) of the DAG, where each
+// edge is a finish-to-start dependency and duration gives the time needed to
+// complete each node. It returns the earliest and latest start and finish
+// times and slack for every node, along with a critical path.
+func (d *DAG[T]) CriticalPath(duration func(v T) time.Duration) *Schedule[T] {
+	schedule := &Schedule[T]{
+		tasks: make(map[T]*Task[T], len(d.nodes)),
+	}
+
+	sorted, err := d.Traverse()
+	if err != nil {
+		return schedule
+	}
+
+	// Forward pass: earliest times
+	for _, node := range sorted {
+		task := &Task[T]{Data: node.data, Duration: duration(node.data)}
+		for parent := range node.parents {
+			if finish := schedule.tasks[parent.data].EarliestFinish; finish > task.EarliestStart {
+				task.EarliestStart = finish
+			}
+		}
+		task.EarliestFinish = task.EarliestStart + task.Duration
+		if task.EarliestFinish > schedule.Duration {
+			schedule.Duration = task.EarliestFinish
+		}
+		schedule.tasks[node.data] = task
+		schedule.Tasks = append(schedule.Tasks, task)
+	}
+
+	// Backward pass: latest times
+	for i := len(sorted) - 1; i >= 0; i-- {
+		node := sorted[i]
+		task := schedule.tasks[node.data]
+		task.LatestFinish = schedule.Duration
+		for child := range node.children {
+			if start := schedule.tasks[child.data].LatestStart; start < task.LatestFinish {
+				task.LatestFinish = start
+			}
+		}
+		task.LatestStart = task.LatestFinish - task.Duration
+		task.Slack = task.LatestStart - task.EarliestStart
+	}
+
+	// Follow critical nodes from a critical root, choosing at each step
+	// a critical child that starts as soon as the current node finishes.
+	var current *Node[T]
+	for _, node := range sorted {
+		if len(node.parents) == 0 && schedule.tasks[node.data].Critical() {
+			current = node
+			break
+		}
+	}
+	for current != nil {
+		schedule.CriticalPath = append(schedule.CriticalPath, current.data)
+		finish := schedule.tasks[current.data].EarliestFinish
+		var next *Node[T]
+		// Use deterministic iteration order
+		for _, child := range current.Children() {
+			task := schedule.tasks[child.data]
+			if task.Critical() && task.EarliestStart == finish {
+				next = child
+				break
+			}
+		}
+		current = next
+	}
+
+	return schedule
+}
@@ -0,0 +1,68 @@
+package dag
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCriticalPath(t *testing.T) {
+	dag := NewDAG[string]()
+
+	// A -> B -> D -> E and A -> C -> D, with C taking longer than B
+	dag.AddEdge("A", "B")
+	dag.AddEdge("A", "C")
+	dag.AddEdge("B", "D")
+	dag.AddEdge("C", "D")
+	dag.AddEdge("D", "E")
+	dag.AddNode("F")
+
+	durations := map[string]time.Duration{
+		"A": 2 * time.Hour,
+		"B": 1 * time.Hour,
+		"C": 4 * time.Hour,
+		"D": 3 * time.Hour,
+		"E": 1 * time.Hour,
+		"F": 5 * time.Hour,
+	}
+
+	schedule := dag.CriticalPath(func(v string) time.Duration {
+		return durations[v]
+	})
+
+	assert.Equal(t, 10*time.Hour, schedule.Duration)
+	assert.Equal(t, []string{"A", "C", "D", "E"}, schedule.CriticalPath)
+	assert.Len(t, schedule.Tasks, 6, "Expected a task for every node")
+
+	b := schedule.Task("B")
+	assert.Equal(t, 2*time.Hour, b.EarliestStart)
+	assert.Equal(t, 3*time.Hour, b.EarliestFinish)
+	assert.Equal(t, 5*time.Hour, b.LatestStart)
+	assert.Equal(t, 6*time.Hour, b.LatestFinish)
+	assert.Equal(t, 3*time.Hour, b.Slack)
+	assert.False(t, b.Critical())
+
+	d := schedule.Task("D")
+	assert.Equal(t, 6*time.Hour, d.EarliestStart)
+	assert.Equal(t, 6*time.Hour, d.LatestStart)
+	assert.True(t, d.Critical())
+
+	f := schedule.Task("F")
+	assert.Equal(t, time.Duration(0), f.EarliestStart)
+	assert.Equal(t, 5*time.Hour, f.Slack, "Expected independent node to have slack up to the total duration")
+
+	assert.Nil(t, schedule.Task("Z"), "Expected nil for a non-existent node")
+}
+
+func TestCriticalPathEmpty(t *testing.T) {
+	dag := NewDAG[string]()
+
+	schedule := dag.CriticalPath(func(v string) time.Duration {
+		return time.Second
+	})
+
+	assert.Empty(t, schedule.Tasks)
+	assert.Empty(t, schedule.CriticalPath)
+	assert.Equal(t, time.Duration(0), schedule.Duration)
+}
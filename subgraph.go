@@ -0,0 +1,74 @@
+package dag
+
+// InducedSubgraph returns a new DAG holding the nodes with the given data and
+// every edge between them. Node and edge attributes, edge weights and the
+// node ordering are preserved. Data that is not in the DAG is ignored.
+func (d *DAG[T]) InducedSubgraph(nodes ...T) *DAG[T] {
+	selected := make(map[*Node[T]]struct{}, len(nodes))
+	for _, node := range d.existingNodes(nodes) {
+		selected[node] = struct{}{}
+	}
+	return d.copySelected(selected)
+}
+
+// Subgraph returns a new DAG holding the nodes with the given data, all of
+// their descendants, and every edge between them, as for InducedSubgraph.
+func (d *DAG[T]) Subgraph(roots ...T) *DAG[T] {
+	selected := make(map[*Node[T]]struct{})
+	walkFrom(d.existingNodes(roots), true, func(node *Node[T]) bool {
+		selected[node] = struct{}{}
+		return true
+	})
+	return d.copySelected(selected)
+}
+
+// Between returns a new DAG holding every node on a path from the node with
+// data 'from' to the node with data 'to', including both, and every edge
+// between them, as for InducedSubgraph. The DAG is empty if there is no
+// such path.
+func (d *DAG[T]) Between(from, to T) *DAG[T] {
+	fromNode, toNode := d.nodes[from], d.nodes[to]
+	if fromNode == nil || toNode == nil {
+		return d.copySelected(nil)
+	}
+
+	reachable := make(map[*Node[T]]struct{})
+	walkFrom([]*Node[T]{fromNode}, true, func(node *Node[T]) bool {
+		reachable[node] = struct{}{}
+		return true
+	})
+	// Ancestors of 'to' outside the descendants of 'from' can't lead back
+	// into them, so the search stops there
+	selected := make(map[*Node[T]]struct{})
+	walkFrom([]*Node[T]{toNode}, false, func(node *Node[T]) bool {
+		if _, ok := reachable[node]; !ok {
+			return false
+		}
+		selected[node] = struct{}{}
+		return true
+	})
+	return d.copySelected(selected)
+}
+
+// copySelected returns a new DAG with the same ordering as this one, holding
+// the selected nodes with their attributes and the edges between them with
+// their weights and attributes.
+func (d *DAG[T]) copySelected(selected map[*Node[T]]struct{}) *DAG[T] {
+	copied := d.emptyCopy()
+	// Add in node order so that insertion order is preserved
+	for _, node := range d.Nodes() {
+		if _, ok := selected[node]; ok {
+			copied.AddNode(node.data).attrs.copyFrom(&node.attrs)
+		}
+	}
+	for node := range selected {
+		for child, weight := range node.children {
+			if _, ok := selected[child]; ok {
+				copied.link(node.data, child.data, weight)
+				copied.copyEdgeAttrs(node, child)
+			}
+		}
+	}
+	copied.renumber()
+	return copied
+}
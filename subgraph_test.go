@@ -0,0 +1,84 @@
+package dag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSubgraphDAG() *DAG[string] {
+	dag := NewDAG(WithInsertionOrder[string]())
+	dag.AddEdge("A", "B")
+	dag.AddEdge("A", "C")
+	dag.AddEdge("B", "D")
+	dag.AddEdge("C", "D")
+	dag.AddWeightedEdge("D", "E", 3)
+	dag.AddEdge("X", "C")
+	dag.AddEdge("A", "E")
+	dag.Node("C").Attrs().Set("color", "red")
+	dag.EdgeAttrs("C", "D").Set("kind", "build")
+	return dag
+}
+
+func edgeData[T comparable](dag *DAG[T]) [][2]T {
+	var edges [][2]T
+	for _, edge := range dag.Edges() {
+		edges = append(edges, [2]T{edge[0].Data(), edge[1].Data()})
+	}
+	return edges
+}
+
+func TestInducedSubgraph(t *testing.T) {
+	dag := newSubgraphDAG()
+	sub := dag.InducedSubgraph("E", "C", "D", "missing")
+
+	assert.Equal(t, []string{"C", "D", "E"}, nodeData(sub.Nodes()), "Expected the original insertion order")
+	assert.Equal(t, [][2]string{{"C", "D"}, {"D", "E"}}, edgeData(sub))
+	weight, _ := sub.EdgeWeight("D", "E")
+	assert.Equal(t, 3.0, weight)
+	color, _ := sub.Node("C").Attrs().Get("color")
+	assert.Equal(t, "red", color)
+	kind, _ := sub.EdgeAttrs("C", "D").Get("kind")
+	assert.Equal(t, "build", kind)
+	assertTopologicalOrder(t, sub)
+
+	// The subgraph is independent of the original
+	sub.Node("C").Attrs().Set("color", "blue")
+	assert.NoError(t, sub.AddEdge("E", "Z"))
+	color, _ = dag.Node("C").Attrs().Get("color")
+	assert.Equal(t, "red", color)
+	assert.Nil(t, dag.Node("Z"))
+
+	assert.Empty(t, dag.InducedSubgraph().Nodes())
+}
+
+func TestSubgraph(t *testing.T) {
+	dag := newSubgraphDAG()
+
+	sub := dag.Subgraph("C")
+	assert.Equal(t, []string{"C", "D", "E"}, nodeData(sub.Nodes()))
+	assert.Equal(t, [][2]string{{"C", "D"}, {"D", "E"}}, edgeData(sub))
+
+	sub = dag.Subgraph("B", "X")
+	assert.Equal(t, []string{"B", "C", "D", "E", "X"}, nodeData(sub.Nodes()))
+	assert.Equal(t, [][2]string{{"B", "D"}, {"C", "D"}, {"D", "E"}, {"X", "C"}}, edgeData(sub))
+	assertTopologicalOrder(t, sub)
+}
+
+func TestBetween(t *testing.T) {
+	dag := newSubgraphDAG()
+
+	sub := dag.Between("A", "D")
+	assert.Equal(t, []string{"A", "B", "C", "D"}, nodeData(sub.Nodes()))
+	assert.Equal(t, [][2]string{{"A", "B"}, {"A", "C"}, {"B", "D"}, {"C", "D"}}, edgeData(sub))
+	kind, _ := sub.EdgeAttrs("C", "D").Get("kind")
+	assert.Equal(t, "build", kind)
+
+	sub = dag.Between("A", "E")
+	assert.Len(t, sub.Nodes(), 5, "Expected X to be left out")
+	assert.True(t, sub.HasEdge("A", "E"))
+
+	assert.Equal(t, []string{"D"}, nodeData(dag.Between("D", "D").Nodes()))
+	assert.Empty(t, dag.Between("B", "C").Nodes())
+	assert.Empty(t, dag.Between("A", "missing").Nodes())
+}
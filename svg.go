@@ -0,0 +1,150 @@
+package dag
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Sizes of arrowheads drawn at the end of edges, in pixels.
+const (
+	svgArrowLength = 10.0
+	svgArrowWidth  = 4.0
+)
+
+// VisualizeSVG renders the DAG as an SVG image using a built-in layered
+// layout, so no external tools such as Graphviz are needed. Nodes are ranked
+// by LevelOrder, ordered within ranks to reduce edge crossings, and drawn as
+// labeled boxes joined by arrows.
+//
+// It accepts the same options as VisualizeWithOptions, except for clusters,
+// which are ignored. The "label", "shape" (box or ellipse), "color",
+// "fillcolor", "fontcolor", "penwidth" and "style" attributes are applied,
+// and "label" is also drawn on edges.
+func (d *DAG[T]) VisualizeSVG(opts VisualizeOptions[T]) string {
+	s := newStyler(opts)
+	attrs := make(map[*Node[T]]map[string]string, len(d.nodes))
+	for _, node := range d.nodes {
+		attrs[node] = s.nodeAttrs(node)
+	}
+	l := newLayout(d, func(node *Node[T]) string {
+		return exportLabel(node, attrs[node])
+	}, opts.RankDir)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %[1]s %[2]s" font-family="sans-serif" font-size="14">`+"\n", svgNum(l.width), svgNum(l.height))
+	if opts.Name != "" {
+		fmt.Fprintf(&sb, "  <title>%s</title>\n", html.EscapeString(opts.Name))
+	}
+
+	// Edges go first so that nodes are drawn over them
+	for _, edge := range l.edges {
+		l.writeEdge(&sb, edge, s.edgeAttrs(edge.from, edge.to))
+	}
+	for _, node := range d.Nodes() {
+		l.writeNode(&sb, l.nodes[node], attrs[node])
+	}
+
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// writeNode draws a node as a box or ellipse with a centered label.
+func (l *layout[T]) writeNode(sb *strings.Builder, n *layoutNode[T], attrs map[string]string) {
+	fill := svgAttr(attrs, "fillcolor", "white")
+	stroke := svgStroke(attrs)
+	switch attrs["shape"] {
+	case "ellipse", "oval", "circle":
+		fmt.Fprintf(sb, `  <ellipse cx="%s" cy="%s" rx="%s" ry="%s" fill="%s"%s/>`+"\n",
+			svgNum(n.x), svgNum(n.y), svgNum(n.width/2), svgNum(n.height/2), fill, stroke)
+	default:
+		fmt.Fprintf(sb, `  <rect x="%s" y="%s" width="%s" height="%s" rx="4" fill="%s"%s/>`+"\n",
+			svgNum(n.x-n.width/2), svgNum(n.y-n.height/2), svgNum(n.width), svgNum(n.height), fill, stroke)
+	}
+	fmt.Fprintf(sb, `  <text x="%s" y="%s" text-anchor="middle" dominant-baseline="central" fill="%s">%s</text>`+"\n",
+		svgNum(n.x), svgNum(n.y), svgAttr(attrs, "fontcolor", "black"), html.EscapeString(n.label))
+}
+
+// writeEdge draws an edge as a line through its bend points, ending in an
+// arrowhead at the border of the target node.
+func (l *layout[T]) writeEdge(sb *strings.Builder, edge layoutEdge[T], attrs map[string]string) {
+	last := len(edge.points) - 1
+	points := make([][2]float64, len(edge.points))
+	for i, p := range edge.points {
+		points[i] = [2]float64{p.x, p.y}
+	}
+	points[0] = l.port(edge.points[0], edge.points[1])
+	points[last] = l.port(edge.points[last], edge.points[last-1])
+
+	coords := make([]string, len(points))
+	for i, p := range points {
+		coords[i] = svgNum(p[0]) + "," + svgNum(p[1])
+	}
+	color := svgAttr(attrs, "color", "black")
+	fmt.Fprintf(sb, `  <polyline points="%s" fill="none"%s/>`+"\n", strings.Join(coords, " "), svgStroke(attrs))
+
+	// Arrowhead pointing along the last segment
+	tip, prev := points[last], points[last-1]
+	dx, dy := tip[0]-prev[0], tip[1]-prev[1]
+	length := math.Hypot(dx, dy)
+	if length > 0 {
+		ux, uy := dx/length, dy/length
+		bx, by := tip[0]-ux*svgArrowLength, tip[1]-uy*svgArrowLength
+		fmt.Fprintf(sb, `  <polygon points="%s,%s %s,%s %s,%s" fill="%s"/>`+"\n",
+			svgNum(tip[0]), svgNum(tip[1]),
+			svgNum(bx-uy*svgArrowWidth), svgNum(by+ux*svgArrowWidth),
+			svgNum(bx+uy*svgArrowWidth), svgNum(by-ux*svgArrowWidth),
+			html.EscapeString(color))
+	}
+
+	if label, exists := attrs["label"]; exists {
+		// Place the label beside the middle of the edge
+		mid := len(points) / 2
+		x, y := (points[mid-1][0]+points[mid][0])/2, (points[mid-1][1]+points[mid][1])/2
+		fmt.Fprintf(sb, `  <text x="%s" y="%s" dominant-baseline="central" font-size="12">%s</text>`+"\n",
+			svgNum(x+6), svgNum(y), html.EscapeString(label))
+	}
+}
+
+// port returns the point where an edge from n towards other meets the border
+// of n. Bend points have no border.
+func (l *layout[T]) port(n, other *layoutNode[T]) [2]float64 {
+	if n.node == nil {
+		return [2]float64{n.x, n.y}
+	}
+	if l.horizontal {
+		return [2]float64{n.x + math.Copysign(n.width/2, other.x-n.x), n.y}
+	}
+	return [2]float64{n.x, n.y + math.Copysign(n.height/2, other.y-n.y)}
+}
+
+// svgAttr returns the escaped value of an attribute, or fallback if unset.
+func svgAttr(attrs map[string]string, key, fallback string) string {
+	if value, exists := attrs[key]; exists {
+		return html.EscapeString(value)
+	}
+	return fallback
+}
+
+// svgStroke returns the stroke presentation attributes for a node or edge,
+// with a leading space.
+func svgStroke(attrs map[string]string) string {
+	result := fmt.Sprintf(` stroke="%s"`, svgAttr(attrs, "color", "black"))
+	if width, exists := attrs["penwidth"]; exists {
+		result += fmt.Sprintf(` stroke-width="%s"`, html.EscapeString(width))
+	}
+	switch style := attrs["style"]; {
+	case strings.Contains(style, "dashed"):
+		result += ` stroke-dasharray="6 4"`
+	case strings.Contains(style, "dotted"):
+		result += ` stroke-dasharray="2 3"`
+	}
+	return result
+}
+
+// svgNum formats a coordinate with at most one decimal place.
+func svgNum(v float64) string {
+	return strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64)
+}
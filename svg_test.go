@@ -0,0 +1,74 @@
+package dag
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVisualizeSVG(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("B", "<C & D>")
+	dag.AddNode("E")
+	dag.EdgeAttrs("A", "B").Set("label", "uses")
+
+	opts := VisualizeOptions[string]{
+		Name:      "deps",
+		Highlight: [][]string{{"A", "B"}},
+		NodeAttrs: func(node *Node[string]) map[string]string {
+			if node.Data() == "E" {
+				return map[string]string{"shape": "ellipse", "fillcolor": "#eee"}
+			}
+			return nil
+		},
+	}
+	svg := dag.VisualizeSVG(opts)
+	assert.Equal(t, svg, dag.VisualizeSVG(opts), "Expected deterministic output")
+
+	// The output is well-formed XML
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	counts := make(map[string]int)
+	var texts []string
+	inText := false
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			return
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			counts[tok.Name.Local]++
+			inText = tok.Name.Local == "text"
+		case xml.CharData:
+			if inText {
+				texts = append(texts, string(tok))
+			}
+		case xml.EndElement:
+			inText = false
+		}
+	}
+
+	assert.Equal(t, 1, counts["svg"])
+	assert.Equal(t, 1, counts["title"])
+	assert.Equal(t, 3, counts["rect"])
+	assert.Equal(t, 1, counts["ellipse"], "Expected the isolated node to be drawn")
+	assert.Equal(t, 2, counts["polyline"])
+	assert.Equal(t, 2, counts["polygon"], "Expected an arrowhead for each edge")
+	assert.ElementsMatch(t, []string{"A", "B", "<C & D>", "E", "uses"}, texts)
+	assert.Contains(t, svg, `fill="#eee"`)
+	assert.Contains(t, svg, `stroke="red" stroke-width="2"`)
+}
+
+func TestVisualizeSVGEmpty(t *testing.T) {
+	dag := NewDAG[string]()
+	svg := dag.VisualizeSVG(VisualizeOptions[string]{})
+	assert.Contains(t, svg, `width="40" height="40"`)
+	assert.True(t, strings.HasSuffix(svg, "</svg>\n"))
+}
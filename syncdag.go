@@ -0,0 +1,301 @@
+package dag
+
+import "sync"
+
+// SyncDAG is a DAG that is safe for concurrent use. Reads share a lock and
+// mutations take it exclusively.
+//
+// Unlike DAG, query methods return node data rather than *Node values, since
+// nodes are live views into the graph and would race with later mutations.
+// Walk callbacks run after the lock is released, over a snapshot of the
+// traversal taken while it was held, so they may freely call back into the
+// SyncDAG.
+type SyncDAG[T comparable] struct {
+	mu  sync.RWMutex
+	dag *DAG[T]
+}
+
+// NewSyncDAG creates and returns a new empty SyncDAG configured with opts.
+func NewSyncDAG[T comparable](opts ...Option[T]) *SyncDAG[T] {
+	return &SyncDAG[T]{
+		dag: NewDAG(opts...),
+	}
+}
+
+// View calls fn with the underlying DAG while holding the read lock.
+// fn must not mutate the DAG or retain any nodes after it returns.
+func (s *SyncDAG[T]) View(fn func(d *DAG[T])) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fn(s.dag)
+}
+
+// Mutate calls fn with the underlying DAG while holding the write lock,
+// returning the error returned by fn. fn must not retain any nodes after
+// it returns.
+func (s *SyncDAG[T]) Mutate(fn func(d *DAG[T]) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.dag)
+}
+
+// AddNode adds a node with the given data to the DAG.
+func (s *SyncDAG[T]) AddNode(data T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dag.AddNode(data)
+}
+
+// AddEdge adds a directed edge from the node with data 'from' to the node with data 'to'.
+// It returns a *CycleError if adding the edge would create a cycle.
+func (s *SyncDAG[T]) AddEdge(from, to T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dag.AddEdge(from, to)
+}
+
+// AddWeightedEdge adds a directed edge from the node with data 'from' to the
+// node with data 'to' carrying the given weight.
+// It returns a *CycleError if adding the edge would create a cycle.
+func (s *SyncDAG[T]) AddWeightedEdge(from, to T, weight float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dag.AddWeightedEdge(from, to, weight)
+}
+
+// RemoveNode removes the node with the given data from the DAG.
+func (s *SyncDAG[T]) RemoveNode(data T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dag.RemoveNode(data)
+}
+
+// RemoveEdge removes the directed edge from the node with data 'from' to the node with data 'to'.
+func (s *SyncDAG[T]) RemoveEdge(from, to T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dag.RemoveEdge(from, to)
+}
+
+// Clear removes all nodes and edges from the DAG.
+func (s *SyncDAG[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dag.Clear()
+}
+
+// HasNode checks if a node with the given data exists in the DAG.
+func (s *SyncDAG[T]) HasNode(data T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dag.Node(data) != nil
+}
+
+// Nodes returns the data of all nodes in the DAG.
+func (s *SyncDAG[T]) Nodes() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return nodeData(s.dag.Nodes())
+}
+
+// Edges returns all edges in the DAG as pairs of node data.
+func (s *SyncDAG[T]) Edges() [][2]T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var edges [][2]T
+	for _, edge := range s.dag.Edges() {
+		edges = append(edges, [2]T{edge[0].data, edge[1].data})
+	}
+	return edges
+}
+
+// Parents returns the data of the parents of the node with the given data.
+func (s *SyncDAG[T]) Parents(data T) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	node := s.dag.Node(data)
+	if node == nil {
+		return nil
+	}
+	return nodeData(node.Parents())
+}
+
+// Children returns the data of the children of the node with the given data.
+func (s *SyncDAG[T]) Children(data T) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	node := s.dag.Node(data)
+	if node == nil {
+		return nil
+	}
+	return nodeData(node.Children())
+}
+
+// Traverse performs a topological sort of the DAG and returns the node data in sorted order.
+func (s *SyncDAG[T]) Traverse() ([]T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sorted, err := s.dag.Traverse()
+	return nodeData(sorted), err
+}
+
+// LevelOrder returns the node data of the DAG in level order.
+func (s *SyncDAG[T]) LevelOrder() [][]T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var levels [][]T
+	for _, level := range s.dag.LevelOrder() {
+		levels = append(levels, nodeData(level))
+	}
+	return levels
+}
+
+// Walk performs a depth-first traversal starting from the node with the given data.
+func (s *SyncDAG[T]) Walk(start T, visit func(v T)) {
+	s.replay(func(record func(v T)) { s.dag.Walk(start, record) }, visit)
+}
+
+// ReverseWalk performs a depth-first traversal in reverse
+// starting from the node with the given data.
+func (s *SyncDAG[T]) ReverseWalk(start T, visit func(v T)) {
+	s.replay(func(record func(v T)) { s.dag.ReverseWalk(start, record) }, visit)
+}
+
+// BreadthFirstWalk performs a breadth-first traversal starting from the node with the given data.
+func (s *SyncDAG[T]) BreadthFirstWalk(start T, visit func(v T)) {
+	s.replay(func(record func(v T)) { s.dag.BreadthFirstWalk(start, record) }, visit)
+}
+
+// ReverseBreadthFirstWalk performs a breadth-first traversal in reverse
+// starting from the node with the given data.
+func (s *SyncDAG[T]) ReverseBreadthFirstWalk(start T, visit func(v T)) {
+	s.replay(func(record func(v T)) { s.dag.ReverseBreadthFirstWalk(start, record) }, visit)
+}
+
+// replay runs walk under the read lock, recording the visit order,
+// then calls visit for each recorded value after the lock is released.
+func (s *SyncDAG[T]) replay(walk func(record func(v T)), visit func(v T)) {
+	var order []T
+	s.mu.RLock()
+	walk(func(v T) {
+		order = append(order, v)
+	})
+	s.mu.RUnlock()
+
+	for _, v := range order {
+		visit(v)
+	}
+}
+
+// Search finds the data of a node matching the predicate function.
+// The predicate is called while the read lock is held, so it must not
+// call back into the SyncDAG.
+func (s *SyncDAG[T]) Search(predicate func(v T) bool) (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	node := s.dag.Search(func(node *Node[T]) bool {
+		return predicate(node.data)
+	})
+	if node == nil {
+		var zero T
+		return zero, false
+	}
+	return node.data, true
+}
+
+// Roots returns the data of all root nodes (nodes with no parents) in the DAG.
+func (s *SyncDAG[T]) Roots() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return nodeData(s.dag.Roots())
+}
+
+// Leaves returns the data of all leaf nodes (nodes with no children) in the DAG.
+func (s *SyncDAG[T]) Leaves() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return nodeData(s.dag.Leaves())
+}
+
+// Visualize generates a DOT format representation of the DAG for visualization.
+func (s *SyncDAG[T]) Visualize() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dag.Visualize()
+}
+
+// HasEdge checks if there is a directed edge from the node with data 'from'
+// to the node with data 'to'.
+func (s *SyncDAG[T]) HasEdge(from, to T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dag.HasEdge(from, to)
+}
+
+// HasPath checks if there is a path from the node with data 'from'
+// to the node with data 'to'.
+func (s *SyncDAG[T]) HasPath(from, to T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dag.HasPath(from, to)
+}
+
+// ShortestPath finds the shortest path from the node with data 'from'
+// to the node with data 'to'.
+func (s *SyncDAG[T]) ShortestPath(from, to T) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return nodeData(s.dag.ShortestPath(from, to))
+}
+
+// EdgeWeight returns the weight of the edge from the node with data 'from'
+// to the node with data 'to', and whether the edge exists.
+func (s *SyncDAG[T]) EdgeWeight(from, to T) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dag.EdgeWeight(from, to)
+}
+
+// ShortestWeightedPath finds the path from the node with data 'from' to the
+// node with data 'to' with the smallest total edge weight.
+func (s *SyncDAG[T]) ShortestWeightedPath(from, to T) ([]T, float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	path, weight := s.dag.ShortestWeightedPath(from, to)
+	return nodeData(path), weight
+}
+
+// LongestWeightedPath finds the path from the node with data 'from' to the
+// node with data 'to' with the largest total edge weight.
+func (s *SyncDAG[T]) LongestWeightedPath(from, to T) ([]T, float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	path, weight := s.dag.LongestWeightedPath(from, to)
+	return nodeData(path), weight
+}
+
+// Ancestors returns the data of all ancestor nodes of the node with the given data.
+func (s *SyncDAG[T]) Ancestors(data T) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return nodeData(s.dag.Ancestors(data))
+}
+
+// Descendants returns the data of all descendant nodes of the node with the given data.
+func (s *SyncDAG[T]) Descendants(data T) []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return nodeData(s.dag.Descendants(data))
+}
+
+// nodeData returns the data of each node, preserving order.
+func nodeData[T comparable](nodes []*Node[T]) []T {
+	if nodes == nil {
+		return nil
+	}
+	data := make([]T, len(nodes))
+	for i, node := range nodes {
+		data[i] = node.data
+	}
+	return data
+}
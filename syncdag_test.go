@@ -0,0 +1,96 @@
+package dag
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncDAG(t *testing.T) {
+	dag := NewSyncDAG[string]()
+
+	assert.NoError(t, dag.AddEdge("A", "B"))
+	assert.NoError(t, dag.AddEdge("A", "C"))
+	assert.NoError(t, dag.AddEdge("B", "D"))
+	assert.NoError(t, dag.AddEdge("C", "D"))
+	assert.ErrorIs(t, dag.AddEdge("D", "A"), ErrCycleDetected)
+
+	assert.True(t, dag.HasNode("A"))
+	assert.Len(t, dag.Nodes(), 4)
+	assert.Len(t, dag.Edges(), 4)
+	assert.Equal(t, []string{"B", "C"}, dag.Children("A"))
+	assert.Equal(t, []string{"B", "C"}, dag.Parents("D"))
+	assert.Equal(t, []string{"A"}, dag.Roots())
+	assert.Equal(t, []string{"D"}, dag.Leaves())
+	assert.Equal(t, [][]string{{"A"}, {"B", "C"}, {"D"}}, dag.LevelOrder())
+	assert.Equal(t, []string{"A", "B", "D"}, dag.ShortestPath("A", "D"))
+	assert.ElementsMatch(t, []string{"A", "B", "C"}, dag.Ancestors("D"))
+	assert.ElementsMatch(t, []string{"B", "C", "D"}, dag.Descendants("A"))
+	assert.True(t, dag.HasEdge("A", "B"))
+	assert.True(t, dag.HasPath("A", "D"))
+
+	sorted, err := dag.Traverse()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "B", "C", "D"}, sorted)
+
+	found, ok := dag.Search(func(v string) bool { return v == "C" })
+	assert.True(t, ok)
+	assert.Equal(t, "C", found)
+
+	dag.RemoveEdge("A", "B")
+	assert.False(t, dag.HasEdge("A", "B"))
+	dag.RemoveNode("D")
+	assert.False(t, dag.HasNode("D"))
+	dag.Clear()
+	assert.Empty(t, dag.Nodes())
+}
+
+func TestSyncDAGWalkSnapshot(t *testing.T) {
+	dag := NewSyncDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("B", "C")
+
+	// Mutating from inside the callback must not deadlock, and the walk
+	// should continue over the snapshot taken when it started.
+	var visited []string
+	dag.Walk("A", func(v string) {
+		visited = append(visited, v)
+		dag.RemoveNode("C")
+	})
+
+	assert.Equal(t, []string{"A", "B", "C"}, visited)
+	assert.False(t, dag.HasNode("C"))
+
+	var reverse []string
+	dag.ReverseBreadthFirstWalk("B", func(v string) {
+		reverse = append(reverse, v)
+	})
+	assert.Equal(t, []string{"B", "A"}, reverse)
+}
+
+func TestSyncDAGConcurrentAccess(t *testing.T) {
+	dag := NewSyncDAG[int]()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				dag.AddEdge(i*100+j, i*100+j+1)
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				dag.Walk(i*100, func(v int) {})
+				dag.Descendants(i * 100)
+				dag.Traverse()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, dag.Nodes(), 8*51)
+}
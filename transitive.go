@@ -0,0 +1,97 @@
+package dag
+
+// TransitiveReduction returns a new DAG with the same nodes and reachability
+// as this one, but with the fewest edges: an edge a -> c is dropped whenever
+// c is also reachable through another path such as a -> b -> c.
+// Node attributes, and the weights and attributes of the remaining edges,
+// are preserved.
+func (d *DAG[T]) TransitiveReduction() *DAG[T] {
+	reduced := d.copyNodes()
+	reach := d.descendantSets()
+
+	for _, node := range d.nodes {
+		// Anything reachable through a child is reachable without
+		// a direct edge.
+		indirect := make(map[*Node[T]]struct{})
+		for child := range node.children {
+			for descendant := range reach[child] {
+				indirect[descendant] = struct{}{}
+			}
+		}
+		for child, weight := range node.children {
+			if _, redundant := indirect[child]; !redundant {
+				reduced.link(node.data, child.data, weight)
+				reduced.copyEdgeAttrs(node, child)
+			}
+		}
+	}
+	reduced.renumber()
+
+	return reduced
+}
+
+// TransitiveClosure returns a new DAG with the same nodes as this one and an
+// edge from every node to each of its descendants. Node attributes are
+// preserved, existing edges keep their weights and attributes, and added
+// edges use DefaultEdgeWeight.
+func (d *DAG[T]) TransitiveClosure() *DAG[T] {
+	closure := d.copyNodes()
+	reach := d.descendantSets()
+
+	for _, node := range d.nodes {
+		for descendant := range reach[node] {
+			weight, direct := node.children[descendant]
+			if !direct {
+				weight = DefaultEdgeWeight
+			}
+			closure.link(node.data, descendant.data, weight)
+			if direct {
+				closure.copyEdgeAttrs(node, descendant)
+			}
+		}
+	}
+	closure.renumber()
+
+	return closure
+}
+
+// descendantSets returns the set of descendants of every node, computed
+// bottom-up in reverse topological order.
+func (d *DAG[T]) descendantSets() map[*Node[T]]map[*Node[T]]struct{} {
+	sorted, _ := d.Traverse()
+	reach := make(map[*Node[T]]map[*Node[T]]struct{}, len(sorted))
+	for i := len(sorted) - 1; i >= 0; i-- {
+		node := sorted[i]
+		set := make(map[*Node[T]]struct{})
+		for child := range node.children {
+			set[child] = struct{}{}
+			for descendant := range reach[child] {
+				set[descendant] = struct{}{}
+			}
+		}
+		reach[node] = set
+	}
+	return reach
+}
+
+// copyNodes returns a new DAG with the same ordering, node data and node
+// attributes as this one, without any edges.
+func (d *DAG[T]) copyNodes() *DAG[T] {
+	copied := d.emptyCopy()
+	// Add in node order so that insertion order is preserved
+	for _, node := range d.Nodes() {
+		copied.AddNode(node.data).attrs.copyFrom(&node.attrs)
+	}
+	return copied
+}
+
+// link adds an edge between two existing nodes without checking for cycles
+// or maintaining the topological order. It must only be used when the edge
+// is known to be acyclic, such as when copying edges from another DAG, and
+// must be followed by a call to renumber.
+func (d *DAG[T]) link(from, to T, weight float64) {
+	fromNode := d.nodes[from]
+	toNode := d.nodes[to]
+	fromNode.children[toNode] = weight
+	toNode.addParent(fromNode)
+}
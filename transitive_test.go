@@ -0,0 +1,70 @@
+package dag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransitiveReduction(t *testing.T) {
+	dag := NewDAG[string]()
+
+	dag.AddEdge("A", "B")
+	dag.AddEdge("B", "C")
+	dag.AddEdge("C", "D")
+	dag.AddEdge("A", "C")
+	dag.AddEdge("A", "D")
+	dag.AddWeightedEdge("B", "E", 3)
+	dag.AddNode("F")
+
+	reduced := dag.TransitiveReduction()
+
+	assert.Len(t, reduced.Nodes(), 6, "Expected all nodes to be preserved")
+	assert.Len(t, reduced.Edges(), 4)
+	assert.True(t, reduced.HasEdge("A", "B"))
+	assert.True(t, reduced.HasEdge("B", "C"))
+	assert.True(t, reduced.HasEdge("C", "D"))
+	assert.True(t, reduced.HasEdge("B", "E"))
+	assert.False(t, reduced.HasEdge("A", "C"), "Expected redundant edge A -> C to be removed")
+	assert.False(t, reduced.HasEdge("A", "D"), "Expected redundant edge A -> D to be removed")
+	assert.NotNil(t, reduced.Node("F"), "Expected isolated node to be preserved")
+
+	weight, _ := reduced.EdgeWeight("B", "E")
+	assert.Equal(t, 3.0, weight, "Expected edge weight to be preserved")
+
+	// The original DAG is unchanged
+	assert.Len(t, dag.Edges(), 6)
+	assert.True(t, dag.HasEdge("A", "C"))
+}
+
+func TestTransitiveClosure(t *testing.T) {
+	dag := NewDAG[int]()
+
+	dag.AddWeightedEdge(1, 2, 5)
+	dag.AddEdge(2, 3)
+	dag.AddEdge(3, 4)
+	dag.AddNode(5)
+
+	closure := dag.TransitiveClosure()
+
+	assert.Len(t, closure.Nodes(), 5, "Expected all nodes to be preserved")
+	assert.Len(t, closure.Edges(), 6)
+	for _, edge := range [][2]int{{1, 2}, {1, 3}, {1, 4}, {2, 3}, {2, 4}, {3, 4}} {
+		assert.True(t, closure.HasEdge(edge[0], edge[1]), "Expected edge %d -> %d", edge[0], edge[1])
+	}
+
+	weight, _ := closure.EdgeWeight(1, 2)
+	assert.Equal(t, 5.0, weight, "Expected existing edge weight to be preserved")
+	weight, _ = closure.EdgeWeight(1, 4)
+	assert.Equal(t, DefaultEdgeWeight, weight)
+
+	// Reducing the closure gives back the original edges
+	reduced := closure.TransitiveReduction()
+	assert.Len(t, reduced.Edges(), 3)
+	assert.True(t, reduced.HasEdge(1, 2))
+	assert.True(t, reduced.HasEdge(2, 3))
+	assert.True(t, reduced.HasEdge(3, 4))
+
+	// The original DAG is unchanged
+	assert.Len(t, dag.Edges(), 3)
+}
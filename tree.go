@@ -0,0 +1,81 @@
+package dag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TreeOptions configures the output of VisualizeTree.
+type TreeOptions[T comparable] struct {
+	// Start lists the nodes to draw from. If nil, the DAG's roots are used.
+	// Nodes that are not in the DAG are skipped.
+	Start []T
+	// MaxDepth limits how many levels below each start node are drawn.
+	// Zero means no limit.
+	MaxDepth int
+	// Label returns the text drawn for a node. It defaults to the node's
+	// data formatted with %v.
+	Label func(node *Node[T]) string
+}
+
+// VisualizeTree draws the DAG as an indented tree for display in a terminal:
+//
+//	A
+//	├── B
+//	│   └── D
+//	└── C
+//	    └── D (*)
+//
+// Children are drawn in the DAG's node order. A node with several parents is
+// expanded only the first time it is drawn; later occurrences, including
+// those of leaves, are marked with (*). Nodes whose children are cut off by
+// MaxDepth are marked with (...).
+func (d *DAG[T]) VisualizeTree(opts TreeOptions[T]) string {
+	label := opts.Label
+	if label == nil {
+		label = func(node *Node[T]) string {
+			return fmt.Sprint(node.data)
+		}
+	}
+
+	var starts []*Node[T]
+	if opts.Start == nil {
+		starts = d.Roots()
+	}
+	for _, data := range opts.Start {
+		if node := d.nodes[data]; node != nil {
+			starts = append(starts, node)
+		}
+	}
+
+	var sb strings.Builder
+	expanded := make(map[*Node[T]]struct{})
+	var draw func(node *Node[T], prefix, branch, indent string, depth int)
+	draw = func(node *Node[T], prefix, branch, indent string, depth int) {
+		sb.WriteString(prefix + branch + label(node))
+		if _, seen := expanded[node]; seen {
+			sb.WriteString(" (*)\n")
+			return
+		}
+		if opts.MaxDepth > 0 && depth >= opts.MaxDepth && len(node.children) > 0 {
+			sb.WriteString(" (...)\n")
+			return
+		}
+		sb.WriteString("\n")
+		expanded[node] = struct{}{}
+
+		// Use deterministic iteration order
+		children := node.Children()
+		for i, child := range children {
+			if i == len(children)-1 {
+				draw(child, prefix+indent, "└── ", "    ", depth+1)
+			} else {
+				draw(child, prefix+indent, "├── ", "│   ", depth+1)
+			}
+		}
+	}
+	for _, node := range starts {
+		draw(node, "", "", "", 0)
+	}
+	return sb.String()
+}
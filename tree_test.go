@@ -0,0 +1,60 @@
+package dag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVisualizeTree(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("A", "C")
+	dag.AddEdge("B", "D")
+	dag.AddEdge("C", "D")
+	dag.AddEdge("D", "E")
+	dag.AddEdge("C", "E")
+	dag.AddNode("F")
+
+	expected := `A
+├── B
+│   └── D
+│       └── E
+└── C
+    ├── D (*)
+    └── E (*)
+F
+`
+	assert.Equal(t, expected, dag.VisualizeTree(TreeOptions[string]{}))
+
+	expected = `C
+├── D
+│   └── E
+└── E (*)
+`
+	assert.Equal(t, expected, dag.VisualizeTree(TreeOptions[string]{Start: []string{"C"}}))
+
+	expected = `A
+├── B (...)
+└── C (...)
+`
+	assert.Equal(t, expected, dag.VisualizeTree(TreeOptions[string]{Start: []string{"A", "X"}, MaxDepth: 1}))
+
+	expected = `b
+└── d
+    └── e
+`
+	assert.Equal(t, expected, dag.VisualizeTree(TreeOptions[string]{
+		Start: []string{"B"},
+		Label: func(node *Node[string]) string {
+			return strings.ToLower(node.Data())
+		},
+	}))
+}
+
+func TestVisualizeTreeEmpty(t *testing.T) {
+	dag := NewDAG[int]()
+	assert.Equal(t, "", dag.VisualizeTree(TreeOptions[int]{}))
+	assert.Equal(t, "", dag.VisualizeTree(TreeOptions[int]{Start: []int{1}}))
+}
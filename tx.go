@@ -0,0 +1,160 @@
+package dag
+
+// Tx stages a batch of mutations to a DAG within Update. Each mutation is
+// applied immediately, so cycle checks and queries see the staged state,
+// and is undone if the transaction is rolled back.
+type Tx[T comparable] struct {
+	dag  *DAG[T]
+	undo []func()
+}
+
+// Update runs fn in a transaction. If fn returns an error or panics, every
+// mutation made through tx is rolled back, leaving the DAG as it was before
+// Update was called, and the error is returned. Otherwise all of the
+// mutations are kept.
+//
+// Errors from individual operations, such as a *CycleError from AddEdge, only
+// roll back the transaction if fn returns them. tx must not be used after fn
+// returns, and the DAG must not be mutated other than through tx while fn is
+// running.
+func (d *DAG[T]) Update(fn func(tx *Tx[T]) error) error {
+	tx := &Tx[T]{dag: d}
+	var events []Event[T]
+	d.pending = &events
+
+	committed := false
+	defer func() {
+		if !committed {
+			// Events from undoing the mutations are discarded with the rest
+			tx.rollback()
+			d.pending = nil
+			return
+		}
+		d.pending = nil
+		for _, event := range events {
+			d.emit(event)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+// DAG returns the DAG being updated, for queries against the staged state.
+// It must not be mutated directly.
+func (tx *Tx[T]) DAG() *DAG[T] {
+	return tx.dag
+}
+
+// AddNode adds a node with the given data to the DAG.
+// If a node with the same data already exists, it returns the existing node.
+func (tx *Tx[T]) AddNode(data T) *Node[T] {
+	if node := tx.dag.nodes[data]; node != nil {
+		return node
+	}
+	node := tx.dag.AddNode(data)
+	tx.undo = append(tx.undo, func() {
+		delete(tx.dag.nodes, data)
+	})
+	return node
+}
+
+// AddEdge adds a directed edge from the node with data 'from' to the node with data 'to'.
+// It returns a *CycleError if adding the edge would create a cycle.
+func (tx *Tx[T]) AddEdge(from, to T) error {
+	tx.AddNode(from)
+	tx.AddNode(to)
+	if tx.dag.HasEdge(from, to) {
+		return nil
+	}
+	if err := tx.dag.AddEdge(from, to); err != nil {
+		return err
+	}
+	tx.undo = append(tx.undo, func() {
+		tx.dag.RemoveEdge(from, to)
+	})
+	return nil
+}
+
+// AddWeightedEdge adds a directed edge from the node with data 'from' to the
+// node with data 'to' carrying the given weight. If the edge already exists,
+// its weight is updated. It returns a *CycleError if adding the edge would
+// create a cycle.
+func (tx *Tx[T]) AddWeightedEdge(from, to T, weight float64) error {
+	previous, existed := tx.dag.EdgeWeight(from, to)
+	if err := tx.AddEdge(from, to); err != nil {
+		return err
+	}
+	tx.dag.nodes[from].children[tx.dag.nodes[to]] = weight
+	if existed {
+		tx.undo = append(tx.undo, func() {
+			tx.dag.nodes[from].children[tx.dag.nodes[to]] = previous
+		})
+	}
+	return nil
+}
+
+// RemoveNode removes the node with the given data from the DAG.
+func (tx *Tx[T]) RemoveNode(data T) {
+	node := tx.dag.nodes[data]
+	if node == nil {
+		return
+	}
+
+	// Removal leaves the node's own edge maps intact, so the node can be
+	// relinked as it was, keeping its identity and insertion order
+	weights := make(map[*Node[T]]float64, len(node.parents))
+	attrs := make(map[*Node[T]]*Attributes)
+	for parent := range node.parents {
+		weights[parent] = parent.children[node]
+		if edgeAttrs := parent.edgeAttrs[node]; edgeAttrs != nil {
+			attrs[parent] = edgeAttrs
+		}
+	}
+	tx.dag.RemoveNode(data)
+	tx.undo = append(tx.undo, func() {
+		tx.dag.nodes[data] = node
+		for parent := range node.parents {
+			parent.children[node] = weights[parent]
+			if edgeAttrs := attrs[parent]; edgeAttrs != nil {
+				*parent.edgeAttrsTo(node) = *edgeAttrs
+			}
+		}
+		for child := range node.children {
+			child.addParent(node)
+		}
+	})
+}
+
+// RemoveEdge removes the directed edge from the node with data 'from' to the node with data 'to'.
+func (tx *Tx[T]) RemoveEdge(from, to T) {
+	weight, exists := tx.dag.EdgeWeight(from, to)
+	if !exists {
+		return
+	}
+	fromNode, toNode := tx.dag.nodes[from], tx.dag.nodes[to]
+	attrs := fromNode.edgeAttrs[toNode]
+	tx.dag.RemoveEdge(from, to)
+	tx.undo = append(tx.undo, func() {
+		tx.dag.link(from, to, weight)
+		if attrs != nil {
+			*fromNode.edgeAttrsTo(toNode) = *attrs
+		}
+	})
+}
+
+// rollback undoes every staged mutation in reverse order.
+func (tx *Tx[T]) rollback() {
+	if len(tx.undo) == 0 {
+		return
+	}
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		tx.undo[i]()
+	}
+	tx.undo = nil
+	// Restored edges bypass the incremental order, so rebuild it
+	tx.dag.renumber()
+}
@@ -0,0 +1,105 @@
+package dag
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTxTestDAG() *DAG[string] {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddWeightedEdge("B", "C", 2)
+	dag.AddEdge("C", "D")
+	return dag
+}
+
+func TestUpdateCommit(t *testing.T) {
+	dag := newTxTestDAG()
+
+	err := dag.Update(func(tx *Tx[string]) error {
+		tx.AddNode("E")
+		if err := tx.AddEdge("D", "E"); err != nil {
+			return err
+		}
+		if err := tx.AddWeightedEdge("A", "E", 5); err != nil {
+			return err
+		}
+		tx.RemoveEdge("A", "B")
+		tx.RemoveNode("C")
+		assert.True(t, tx.DAG().HasPath("A", "E"), "Expected queries to see staged changes")
+		return nil
+	})
+	assert.NoError(t, err)
+
+	assert.True(t, dag.HasEdge("D", "E"))
+	weight, _ := dag.EdgeWeight("A", "E")
+	assert.Equal(t, 5.0, weight)
+	assert.False(t, dag.HasEdge("A", "B"))
+	assert.Nil(t, dag.Node("C"))
+	assertTopologicalOrder(t, dag)
+}
+
+func TestUpdateRollback(t *testing.T) {
+	dag := newTxTestDAG()
+	before := dag.Visualize()
+	nodeB := dag.Node("B")
+
+	err := dag.Update(func(tx *Tx[string]) error {
+		tx.AddEdge("D", "E")
+		tx.AddWeightedEdge("B", "C", 7)
+		tx.RemoveEdge("A", "B")
+		tx.RemoveNode("B")
+		tx.AddEdge("E", "F")
+		// The staged state has no path from D back to A, so this is allowed
+		if err := tx.AddEdge("D", "A"); err != nil {
+			return err
+		}
+		// But this closes a cycle against the staged state
+		return tx.AddEdge("F", "C")
+	})
+	assert.ErrorIs(t, err, ErrCycleDetected)
+
+	assert.Equal(t, before, dag.Visualize(), "Expected the DAG to be unchanged after rollback")
+	assert.Len(t, dag.Nodes(), 4)
+	assert.Nil(t, dag.Node("E"), "Expected staged nodes to be removed")
+	assert.Same(t, nodeB, dag.Node("B"), "Expected removed nodes to be restored")
+	assert.Equal(t, []string{"C"}, nodeData(nodeB.Children()))
+	assert.Equal(t, []string{"A"}, nodeData(nodeB.Parents()))
+	weight, _ := dag.EdgeWeight("B", "C")
+	assert.Equal(t, 2.0, weight, "Expected edge weights to be restored")
+	assertTopologicalOrder(t, dag)
+
+	// The restored order still detects cycles
+	assert.ErrorIs(t, dag.AddEdge("D", "A"), ErrCycleDetected)
+}
+
+func TestUpdateRollbackOnPanic(t *testing.T) {
+	dag := newTxTestDAG()
+	before := dag.Visualize()
+
+	assert.Panics(t, func() {
+		dag.Update(func(tx *Tx[string]) error {
+			tx.RemoveNode("C")
+			panic("boom")
+		})
+	})
+	assert.Equal(t, before, dag.Visualize(), "Expected the DAG to be unchanged after a panic")
+}
+
+func TestUpdateIgnoredError(t *testing.T) {
+	dag := newTxTestDAG()
+
+	err := dag.Update(func(tx *Tx[string]) error {
+		tx.AddEdge("D", "E")
+		// Errors that are not returned do not roll back the transaction
+		if err := tx.AddEdge("D", "A"); !errors.Is(err, ErrCycleDetected) {
+			return err
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, dag.HasEdge("D", "E"))
+	assert.False(t, dag.HasEdge("D", "A"))
+}
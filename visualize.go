@@ -0,0 +1,157 @@
+package dag
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// VisualizeOptions configures the output of VisualizeWithOptions,
+// VisualizeMermaid and VisualizePlantUML. Attributes are given as DOT-style
+// key/value pairs such as "label", "color" and "shape".
+// The zero value includes every node and applies no styling beyond the
+// attributes stored on nodes and edges.
+type VisualizeOptions[T comparable] struct {
+	// Name is the graph ID. It defaults to "G".
+	Name string
+	// RankDir sets the layout direction: "TB", "LR", "BT" or "RL".
+	RankDir string
+	// NodeAttrs returns attributes for a node, overriding those stored on it.
+	NodeAttrs func(node *Node[T]) map[string]string
+	// EdgeAttrs returns attributes for an edge, overriding those stored on it.
+	EdgeAttrs func(from, to *Node[T]) map[string]string
+	// Cluster returns the name of the cluster a node belongs to, or "" if it
+	// does not belong to one. Each cluster is drawn as a labeled box.
+	Cluster func(node *Node[T]) string
+	// Highlight lists paths, such as a Schedule's CriticalPath, whose nodes
+	// and consecutive edges are drawn with HighlightAttrs.
+	Highlight [][]T
+	// HighlightAttrs are applied to highlighted nodes and edges. They default
+	// to a red, bold stroke.
+	HighlightAttrs map[string]string
+}
+
+// defaultHighlightAttrs are used when VisualizeOptions.HighlightAttrs is nil.
+var defaultHighlightAttrs = map[string]string{"color": "red", "penwidth": "2"}
+
+// styler resolves the attributes of nodes and edges for an exporter.
+type styler[T comparable] struct {
+	opts       VisualizeOptions[T]
+	nodes      map[T]struct{}
+	edges      map[[2]T]struct{}
+	highlights map[string]string
+}
+
+func newStyler[T comparable](opts VisualizeOptions[T]) *styler[T] {
+	s := &styler[T]{
+		opts:       opts,
+		nodes:      make(map[T]struct{}),
+		edges:      make(map[[2]T]struct{}),
+		highlights: opts.HighlightAttrs,
+	}
+	if s.highlights == nil {
+		s.highlights = defaultHighlightAttrs
+	}
+	for _, path := range opts.Highlight {
+		for i, data := range path {
+			s.nodes[data] = struct{}{}
+			if i > 0 {
+				s.edges[[2]T{path[i-1], data}] = struct{}{}
+			}
+		}
+	}
+	return s
+}
+
+// nodeAttrs returns the attributes of a node: those stored on it, then those
+// from the NodeAttrs callback, then the highlight attributes.
+func (s *styler[T]) nodeAttrs(node *Node[T]) map[string]string {
+	attrs := maps.Collect(node.attrs.All())
+	if s.opts.NodeAttrs != nil {
+		maps.Copy(attrs, s.opts.NodeAttrs(node))
+	}
+	if _, highlighted := s.nodes[node.data]; highlighted {
+		maps.Copy(attrs, s.highlights)
+	}
+	return attrs
+}
+
+// edgeAttrs returns the attributes of an edge, resolved like nodeAttrs.
+func (s *styler[T]) edgeAttrs(from, to *Node[T]) map[string]string {
+	attrs := make(map[string]string)
+	if stored := from.edgeAttrs[to]; stored != nil {
+		maps.Insert(attrs, stored.All())
+	}
+	if s.opts.EdgeAttrs != nil {
+		maps.Copy(attrs, s.opts.EdgeAttrs(from, to))
+	}
+	if _, highlighted := s.edges[[2]T{from.data, to.data}]; highlighted {
+		maps.Copy(attrs, s.highlights)
+	}
+	return attrs
+}
+
+// clusters groups nodes by cluster name, in node order. Nodes outside any
+// cluster are listed under "". Names are returned sorted.
+func (s *styler[T]) clusters(nodes []*Node[T]) ([]string, map[string][]*Node[T]) {
+	groups := make(map[string][]*Node[T])
+	for _, node := range nodes {
+		name := ""
+		if s.opts.Cluster != nil {
+			name = s.opts.Cluster(node)
+		}
+		groups[name] = append(groups[name], node)
+	}
+	names := slices.Sorted(maps.Keys(groups))
+	return names, groups
+}
+
+// VisualizeWithOptions generates a DOT format representation of the DAG.
+// Unlike Visualize, every node is listed, including isolated ones, and node
+// attributes, clusters and highlighted paths are applied as configured by
+// opts. Output is deterministic for a given graph and options.
+func (d *DAG[T]) VisualizeWithOptions(opts VisualizeOptions[T]) string {
+	s := newStyler(opts)
+	name := opts.Name
+	if name == "" {
+		name = "G"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "digraph %s {\n", dotAttrName(name))
+	if opts.RankDir != "" {
+		fmt.Fprintf(&sb, "    rankdir=%s;\n", dotQuote(opts.RankDir))
+	}
+
+	nodes := d.Nodes()
+	names, groups := s.clusters(nodes)
+	for _, cluster := range names {
+		indent := "    "
+		if cluster != "" {
+			fmt.Fprintf(&sb, "    subgraph %s {\n", dotQuote("cluster_"+cluster))
+			fmt.Fprintf(&sb, "        label=%s;\n", dotQuote(cluster))
+			indent = "        "
+		}
+		for _, node := range groups[cluster] {
+			fmt.Fprintf(&sb, "%s%s%s;\n", indent, dotQuote(fmt.Sprint(node.data)), dotAttrMap(s.nodeAttrs(node)))
+		}
+		if cluster != "" {
+			sb.WriteString("    }\n")
+		}
+	}
+
+	for _, node := range nodes {
+		// Use deterministic iteration order
+		for _, child := range node.Children() {
+			fmt.Fprintf(&sb, "    %s -> %s%s;\n", dotQuote(fmt.Sprint(node.data)), dotQuote(fmt.Sprint(child.data)), dotAttrMap(s.edgeAttrs(node, child)))
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// dotAttrMap formats attributes like dotAttrList.
+func dotAttrMap(attrs map[string]string) string {
+	return dotAttrList(&Attributes{values: attrs})
+}
@@ -0,0 +1,160 @@
+package dag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVisualizeWithOptions(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("B", "C")
+	dag.AddNode("D")
+
+	// The zero value lists every node, including isolated ones
+	expected := `digraph G {
+    "A";
+    "B";
+    "C";
+    "D";
+    "A" -> "B";
+    "B" -> "C";
+}
+`
+	assert.Equal(t, expected, dag.VisualizeWithOptions(VisualizeOptions[string]{}))
+
+	dag.Node("A").Attrs().Set("shape", "box")
+	dag.EdgeAttrs("A", "B").Set("style", "dashed")
+	dot := dag.VisualizeWithOptions(VisualizeOptions[string]{
+		Name:    "deps",
+		RankDir: "LR",
+		NodeAttrs: func(node *Node[string]) map[string]string {
+			return map[string]string{"label": strings.ToLower(node.Data())}
+		},
+		EdgeAttrs: func(from, to *Node[string]) map[string]string {
+			if from.Data() == "A" {
+				return map[string]string{"style": "dotted"}
+			}
+			return nil
+		},
+	})
+	expected = `digraph deps {
+    rankdir="LR";
+    "A" [label="a", shape="box"];
+    "B" [label="b"];
+    "C" [label="c"];
+    "D" [label="d"];
+    "A" -> "B" [style="dotted"];
+    "B" -> "C";
+}
+`
+	assert.Equal(t, expected, dot, "Expected callbacks to override stored attributes")
+
+	// The output is valid DOT
+	graph, err := ParseDOT(strings.NewReader(dot))
+	assert.NoError(t, err)
+	assert.Len(t, graph.DAG.Nodes(), 4)
+	assert.Equal(t, "LR", graph.GraphAttrs["rankdir"])
+}
+
+func TestVisualizeClusters(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("api", "db")
+	dag.AddEdge("web", "api")
+	dag.AddEdge("web", "cdn")
+	dag.AddNode("docs")
+
+	groups := map[string]string{"api": "backend", "db": "backend", "web": "frontend", "cdn": "frontend"}
+	dot := dag.VisualizeWithOptions(VisualizeOptions[string]{
+		Cluster: func(node *Node[string]) string {
+			return groups[node.Data()]
+		},
+	})
+	expected := `digraph G {
+    "docs";
+    subgraph "cluster_backend" {
+        label="backend";
+        "api";
+        "db";
+    }
+    subgraph "cluster_frontend" {
+        label="frontend";
+        "cdn";
+        "web";
+    }
+    "api" -> "db";
+    "web" -> "api";
+    "web" -> "cdn";
+}
+`
+	assert.Equal(t, expected, dot)
+}
+
+func TestVisualizeHighlight(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddEdge("B", "D")
+	dag.AddEdge("A", "C")
+	dag.AddEdge("C", "D")
+
+	path := nodeData(dag.ShortestPath("A", "D"))
+	dot := dag.VisualizeWithOptions(VisualizeOptions[string]{Highlight: [][]string{path}})
+	expected := `digraph G {
+    "A" [color="red", penwidth="2"];
+    "B" [color="red", penwidth="2"];
+    "C";
+    "D" [color="red", penwidth="2"];
+    "A" -> "B" [color="red", penwidth="2"];
+    "A" -> "C";
+    "B" -> "D" [color="red", penwidth="2"];
+    "C" -> "D";
+}
+`
+	assert.Equal(t, expected, dot)
+
+	dot = dag.VisualizeWithOptions(VisualizeOptions[string]{
+		Highlight:      [][]string{{"C", "D"}},
+		HighlightAttrs: map[string]string{"style": "bold"},
+	})
+	assert.Contains(t, dot, `"C" -> "D" [style="bold"];`)
+	assert.Contains(t, dot, `"A" -> "C";`)
+}
+
+func TestVisualizeEscaping(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge(`a"b`, `c\`)
+	dag.AddEdge(`c\`, `C:\dir\`)
+
+	dot := dag.VisualizeWithOptions(VisualizeOptions[string]{
+		Cluster: func(node *Node[string]) string {
+			if node.Data() == `C:\dir\` {
+				return `C:\`
+			}
+			return ""
+		},
+		NodeAttrs: func(node *Node[string]) map[string]string {
+			return map[string]string{"label": node.Data()}
+		},
+	})
+	expected := `digraph G {
+    "a\"b" [label="a\"b"];
+    "c\\" [label="c\\"];
+    subgraph "cluster_C:\\" {
+        label="C:\\";
+        "C:\\dir\\" [label="C:\\dir\\"];
+    }
+    "a\"b" -> "c\\";
+    "c\\" -> "C:\\dir\\";
+}
+`
+	assert.Equal(t, expected, dot)
+
+	// Quotes and backslashes in node data survive a round trip
+	graph, err := ParseDOT(strings.NewReader(dot))
+	assert.NoError(t, err)
+	assert.True(t, graph.DAG.HasEdge(`a"b`, `c\`))
+	assert.True(t, graph.DAG.HasEdge(`c\`, `C:\dir\`))
+	assert.Equal(t, `C:\dir\`, graph.NodeAttrs[`C:\dir\`]["label"])
+}
@@ -0,0 +1,98 @@
+package dag
+
+// DefaultEdgeWeight is the weight of edges added with AddEdge.
+const DefaultEdgeWeight = 1.0
+
+// AddWeightedEdge adds a directed edge from the node with data 'from' to the
+// node with data 'to' carrying the given weight. If the edge already exists,
+// its weight is updated. It returns a *CycleError if adding the edge would
+// create a cycle.
+func (d *DAG[T]) AddWeightedEdge(from, to T, weight float64) error {
+	if err := d.AddEdge(from, to); err != nil {
+		return err
+	}
+	d.nodes[from].children[d.nodes[to]] = weight
+	return nil
+}
+
+// EdgeWeight returns the weight of the edge from the node with data 'from'
+// to the node with data 'to', and whether the edge exists.
+func (d *DAG[T]) EdgeWeight(from, to T) (float64, bool) {
+	fromNode := d.nodes[from]
+	toNode := d.nodes[to]
+	if fromNode == nil || toNode == nil {
+		return 0, false
+	}
+	weight, exists := fromNode.children[toNode]
+	return weight, exists
+}
+
+// ShortestWeightedPath finds the path from the node with data 'from' to the
+// node with data 'to' with the smallest total edge weight, returning the path
+// and its weight. Edges are relaxed in topological order, so negative weights
+// are handled. The path is nil if 'to' is not reachable.
+func (d *DAG[T]) ShortestWeightedPath(from, to T) ([]*Node[T], float64) {
+	return d.relaxedPath(from, to, func(candidate, current float64) bool {
+		return candidate < current
+	})
+}
+
+// LongestWeightedPath finds the path from the node with data 'from' to the
+// node with data 'to' with the largest total edge weight, returning the path
+// and its weight, by relaxing edges in topological order. The path is nil if
+// 'to' is not reachable.
+func (d *DAG[T]) LongestWeightedPath(from, to T) ([]*Node[T], float64) {
+	return d.relaxedPath(from, to, func(candidate, current float64) bool {
+		return candidate > current
+	})
+}
+
+// relaxedPath computes the best path between two nodes by relaxing every edge
+// in topological order, where better reports whether a candidate distance
+// should replace the current one.
+func (d *DAG[T]) relaxedPath(from, to T, better func(candidate, current float64) bool) ([]*Node[T], float64) {
+	fromNode := d.nodes[from]
+	toNode := d.nodes[to]
+	if fromNode == nil || toNode == nil {
+		return nil, 0
+	}
+
+	sorted, err := d.Traverse()
+	if err != nil {
+		return nil, 0
+	}
+
+	dist := map[*Node[T]]float64{fromNode: 0}
+	prev := make(map[*Node[T]]*Node[T])
+	for _, node := range sorted {
+		nodeDist, reached := dist[node]
+		if !reached {
+			continue
+		}
+		if node == toNode {
+			break
+		}
+		// Use deterministic iteration order
+		for _, child := range node.Children() {
+			candidate := nodeDist + node.children[child]
+			if current, seen := dist[child]; !seen || better(candidate, current) {
+				dist[child] = candidate
+				prev[child] = node
+			}
+		}
+	}
+
+	total, reached := dist[toNode]
+	if !reached {
+		return nil, 0
+	}
+
+	var path []*Node[T]
+	for node := toNode; node != nil; node = prev[node] {
+		path = append(path, node)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, total
+}
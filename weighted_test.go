@@ -0,0 +1,104 @@
+package dag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddWeightedEdge(t *testing.T) {
+	dag := NewDAG[string]()
+
+	assert.NoError(t, dag.AddEdge("A", "B"))
+	assert.NoError(t, dag.AddWeightedEdge("B", "C", 2.5))
+
+	weight, exists := dag.EdgeWeight("A", "B")
+	assert.True(t, exists)
+	assert.Equal(t, DefaultEdgeWeight, weight, "Expected AddEdge to use the default weight")
+
+	weight, exists = dag.EdgeWeight("B", "C")
+	assert.True(t, exists)
+	assert.Equal(t, 2.5, weight)
+
+	// Re-adding an edge with AddEdge keeps its weight
+	assert.NoError(t, dag.AddEdge("B", "C"))
+	weight, _ = dag.EdgeWeight("B", "C")
+	assert.Equal(t, 2.5, weight)
+
+	// AddWeightedEdge updates the weight of an existing edge
+	assert.NoError(t, dag.AddWeightedEdge("B", "C", 4))
+	weight, _ = dag.EdgeWeight("B", "C")
+	assert.Equal(t, 4.0, weight)
+
+	assert.ErrorIs(t, dag.AddWeightedEdge("C", "A", 1), ErrCycleDetected)
+
+	_, exists = dag.EdgeWeight("A", "C")
+	assert.False(t, exists, "Expected no edge between A and C")
+	_, exists = dag.EdgeWeight("A", "Z")
+	assert.False(t, exists, "Expected no edge to a non-existent node")
+}
+
+func TestWeightedPaths(t *testing.T) {
+	dag := NewDAG[string]()
+
+	// A -> B -> D costs 1 + 1, A -> C -> D costs 5 + 5, A -> D costs 3
+	dag.AddWeightedEdge("A", "B", 1)
+	dag.AddWeightedEdge("B", "D", 1)
+	dag.AddWeightedEdge("A", "C", 5)
+	dag.AddWeightedEdge("C", "D", 5)
+	dag.AddWeightedEdge("A", "D", 3)
+	dag.AddNode("E")
+
+	path, weight := dag.ShortestWeightedPath("A", "D")
+	assert.Equal(t, []string{"A", "B", "D"}, nodeData(path))
+	assert.Equal(t, 2.0, weight)
+
+	path, weight = dag.LongestWeightedPath("A", "D")
+	assert.Equal(t, []string{"A", "C", "D"}, nodeData(path))
+	assert.Equal(t, 10.0, weight)
+
+	// The hop-count shortest path takes the direct edge instead
+	assert.Equal(t, []string{"A", "D"}, nodeData(dag.ShortestPath("A", "D")))
+
+	path, weight = dag.ShortestWeightedPath("A", "A")
+	assert.Equal(t, []string{"A"}, nodeData(path))
+	assert.Equal(t, 0.0, weight)
+
+	path, _ = dag.ShortestWeightedPath("A", "E")
+	assert.Nil(t, path, "Expected no path to an unreachable node")
+	path, _ = dag.LongestWeightedPath("D", "A")
+	assert.Nil(t, path, "Expected no path against edge direction")
+	path, _ = dag.ShortestWeightedPath("A", "Z")
+	assert.Nil(t, path, "Expected no path to a non-existent node")
+}
+
+func TestWeightedPathsNegativeWeights(t *testing.T) {
+	dag := NewDAG[int]()
+
+	dag.AddWeightedEdge(1, 2, 4)
+	dag.AddWeightedEdge(2, 4, -6)
+	dag.AddWeightedEdge(1, 3, 1)
+	dag.AddWeightedEdge(3, 4, 1)
+
+	path, weight := dag.ShortestWeightedPath(1, 4)
+	assert.Equal(t, []int{1, 2, 4}, nodeData(path))
+	assert.Equal(t, -2.0, weight)
+}
+
+func TestWeightedEdgeJSON(t *testing.T) {
+	dag := NewDAG[string]()
+	dag.AddEdge("A", "B")
+	dag.AddWeightedEdge("B", "C", 0.5)
+
+	data, err := json.Marshal(dag)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"nodes":["A","B","C"],"edges":[{"from":0,"to":1},{"from":1,"to":2,"weight":0.5}]}`, string(data))
+
+	var decoded DAG[string]
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	weight, _ := decoded.EdgeWeight("A", "B")
+	assert.Equal(t, DefaultEdgeWeight, weight)
+	weight, _ = decoded.EdgeWeight("B", "C")
+	assert.Equal(t, 0.5, weight)
+}